@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
 	"time"
@@ -25,10 +26,22 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/russross/meddler"
+
+	"github.com/ARR552/agglayer_certificate_spammer/audit"
+	"github.com/ARR552/agglayer_certificate_spammer/fault"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
+	"github.com/ARR552/agglayer_certificate_spammer/transport"
 )
 
 const signatureSize = 65
 
+// epochSubscriberID is the name every AggSender subscribes to its
+// epochNotifier under. It is one constant shared by all instances, so it
+// does not tell several AggSenders on one notifier (e.g. a Coordinator
+// running several AggSenders against one blockNotifier) apart.
+const epochSubscriberID = "aggsender-spammer"
+
 var (
 	errNoBridgesAndClaims   = errors.New("no bridges and claims to build certificate")
 	errInvalidSignatureSize = errors.New("invalid signature size")
@@ -46,15 +59,37 @@ type AggSender struct {
 
 	storage        db.AggSenderStorage
 	aggLayerClient agglayer.AgglayerClientInterface
+	transport      transport.CertificateTransport
+	certSigner     signer.CertSigner
 
 	cfg aggsender.Config
 
-	sequencerKey *ecdsa.PrivateKey
-	Tree         *tree.AppendOnlyTree
+	Tree *tree.AppendOnlyTree
 
 	BridgeDatabase *sql.DB
 
 	status types.AggsenderStatus
+
+	injected injectedState
+
+	limiter   *tokenBucket
+	jitterMax time.Duration
+	metrics   *RateMetrics
+
+	retries                *retryTracker
+	reconciliationInterval time.Duration
+
+	allowReorgRecovery bool
+	history            *CertificateHistory
+	audit              audit.AuditLogger
+	faultPicker        *fault.Picker
+	resultStream       io.Writer
+
+	// epochEvents receives a types.EpochEvent each time epochNotifier starts
+	// a new epoch; sendCertificates drains it non-blockingly to align
+	// emission with epoch boundaries. nil when epochNotifier doesn't support
+	// Subscribe, in which case sendCertificates falls back to rate-only pacing.
+	epochEvents chan types.EpochEvent
 }
 
 // New returns a new AggSender
@@ -66,7 +101,7 @@ func New(
 	l1InfoTreeSyncer *l1infotreesync.L1InfoTreeSync,
 	l2Syncer types.L2BridgeSyncer,
 	epochNotifier types.EpochNotifier,
-	sequencerPrivateKey *ecdsa.PrivateKey,
+	certSigner signer.CertSigner,
 	bridgeDB string,
 ) (*AggSender, error) {
 	storageConfig := db.AggSenderSQLStorageConfig{
@@ -84,21 +119,106 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	history, err := ConnectCertificateHistory(cfg.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// epochNotifier is typed as the narrow types.EpochNotifier interface (just
+	// String() is confirmed used elsewhere in this package), but every
+	// concrete notifier this tree constructs (aggsender.NewEpochNotifierPerBlock)
+	// is expected to also expose Subscribe(id string) chan types.EpochEvent for
+	// epoch-boundary notifications; the optional-interface check below degrades
+	// to rate-only pacing instead of failing New if that assumption is wrong.
+	var epochEvents chan types.EpochEvent
+	if subscriber, ok := epochNotifier.(interface {
+		Subscribe(id string) chan types.EpochEvent
+	}); ok {
+		epochEvents = subscriber.Subscribe(epochSubscriberID)
+	}
+
 	return &AggSender{
-		cfg:              cfg,
-		log:              logger,
-		storage:          storage,
-		l2Syncer:         l2Syncer,
-		aggLayerClient:   aggLayerClient,
-		l1infoTreeSyncer: l1InfoTreeSyncer,
-		sequencerKey:     sequencerPrivateKey,
-		epochNotifier:    epochNotifier,
-		Tree:             tree,
-		BridgeDatabase:   bridgeDatabase,
-		status:           types.AggsenderStatus{Status: types.StatusNone},
+		cfg:                    cfg,
+		log:                    logger,
+		storage:                storage,
+		l2Syncer:               l2Syncer,
+		aggLayerClient:         aggLayerClient,
+		transport:              transport.NewHTTPTransport(aggLayerClient),
+		certSigner:             certSigner,
+		l1infoTreeSyncer:       l1InfoTreeSyncer,
+		epochNotifier:          epochNotifier,
+		Tree:                   tree,
+		BridgeDatabase:         bridgeDatabase,
+		status:                 types.AggsenderStatus{Status: types.StatusNone},
+		limiter:                newTokenBucket(defaultCertsPerSecond, defaultBurst),
+		retries:                newRetryTracker(),
+		reconciliationInterval: defaultReconciliationRetryInterval,
+		history:                history,
+		audit:                  audit.NewLogger(logger, audit.NewStdoutSink()),
+		epochEvents:            epochEvents,
 	}, nil
 }
 
+// SetAuditLogger overrides the default audit trail (JSON lines to stdout),
+// for example to also write a tamper-evident file (audit.NewRotatingFileSink)
+// or publish to a message broker (audit.NewPublisherSink) by wrapping l in
+// an audit.Logger built with several sinks.
+func (a *AggSender) SetAuditLogger(l audit.AuditLogger) {
+	a.audit = l
+}
+
+// SetTransport overrides how certificates are actually sent, replacing the
+// default HTTPTransport built around aggLayerClient. It does not affect
+// certificate status lookups (GetCertificateHeader, GetLatestKnownCertificateHeader),
+// which keep using aggLayerClient directly.
+func (a *AggSender) SetTransport(t transport.CertificateTransport) {
+	a.transport = t
+}
+
+// SetSigner overrides how certificates are actually signed, replacing the
+// certSigner passed into New. This lets the sequencer key be held by a
+// remote Clef-style signer or a KMS/HSM instead of living in process
+// memory. It does not affect the foreign-signer fuzz scenario, which always
+// signs with a freshly generated ephemeral key.
+func (a *AggSender) SetSigner(s signer.CertSigner) {
+	a.certSigner = s
+}
+
+// SetFaultScenario makes the send loop draw its scenarioID from picker on
+// every certificate instead of using the fixed one Start was called with,
+// so a single run can exercise a weighted mix of malformed shapes (and a
+// share of well-formed certificates) as described by a fault.Scenario file.
+// A nil picker (the default) leaves Start's scenarioID argument in control.
+// Either way, a scenario set via SetScenario takes precedence.
+func (a *AggSender) SetFaultScenario(picker *fault.Picker) {
+	a.faultPicker = picker
+}
+
+// SetResultStream makes sendCertificate append one NDJSON ResultEvent line
+// to w after every send attempt, so a CI job can assert on exactly which
+// certificates were accepted/rejected and why without scraping logs. A nil
+// writer (the default) disables the stream.
+func (a *AggSender) SetResultStream(w io.Writer) {
+	a.resultStream = w
+}
+
+// SetReconciliationRetryInterval overrides how often the reconciliation
+// goroutine re-checks certificates that previously failed with a
+// recoverable error. Defaults to defaultReconciliationRetryInterval.
+func (a *AggSender) SetReconciliationRetryInterval(d time.Duration) {
+	a.reconciliationInterval = d
+}
+
+// SetAllowReorgRecovery enables automatic rollback recovery in
+// checkLastCertificateFromAgglayer's CASE 3.1 and CASE 4 (agglayer disagrees
+// with local storage about the last certificate's height or identity).
+// When disabled (the default), both cases fail with an error requiring
+// operator intervention, since rewinding local state to match a reorged
+// agglayer is a destructive, best-effort operation.
+func (a *AggSender) SetAllowReorgRecovery(allow bool) {
+	a.allowReorgRecovery = allow
+}
+
 func (a *AggSender) Info() types.AggsenderInfo {
 	res := types.AggsenderInfo{
 		AggsenderStatus:          a.status,
@@ -109,12 +229,16 @@ func (a *AggSender) Info() types.AggsenderInfo {
 	return res
 }
 
-// Start starts the AggSender
-func (a *AggSender) Start(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool) {
+// Start starts the AggSender. scenarioID, when non-empty, makes every
+// certificate sent a deliberately malformed one matching that scenario,
+// instead of a well-formed one, so the run exercises a specific agglayer
+// rejection path.
+func (a *AggSender) Start(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool, scenarioID ScenarioID) {
 	a.log.Info("AggSender started")
 	a.status.Start(time.Now().UTC())
 	a.checkInitialStatus(ctx)
-	a.sendCertificates(ctx, emptyCert, addFakeBridge, storeCertificate, singleCert)
+	go a.reconciliationLoop(ctx)
+	a.sendCertificates(ctx, emptyCert, addFakeBridge, storeCertificate, singleCert, scenarioID)
 }
 
 // checkInitialStatus check local status vs agglayer status
@@ -139,32 +263,68 @@ func (a *AggSender) checkInitialStatus(ctx context.Context) {
 	}
 }
 
-// sendCertificates sends certificates to the aggLayer
-func (a *AggSender) sendCertificates(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool) {
-	ticker := time.NewTicker(time.Second)
+// sendCertificates drives the send loop: instead of a fixed 1 Hz probe, it
+// waits for a.limiter to grant a token, aligns to the next epoch boundary
+// a.epochNotifier has announced (if any), applies jitter on top of it, then
+// checks for pending certificates before sending the next one. This lets a
+// run be tuned (via SetRateLimit) to simulate bursty or smooth sequencer
+// traffic rather than only ever polling once a second.
+func (a *AggSender) sendCertificates(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool, scenarioID ScenarioID) {
 	a.status.Status = types.StatusCertificateStage
+	var lastEpoch uint64
+	haveEpoch := false
 	for {
-		select {
-		case <-ticker.C:
-			thereArePendingCerts := a.checkPendingCertificatesStatus(ctx)
-			if !thereArePendingCerts {
-				_, err := a.sendCertificate(ctx, emptyCert, addFakeBridge, storeCertificate, singleCert)
-				a.status.SetLastError(err)
-				if err != nil {
-					a.log.Error(err)
-				}
-			} else {
-				log.Infof("Skipping because there are pending certificates")
-		}
-		case <-ctx.Done():
+		if err := a.limiter.wait(ctx); err != nil {
 			a.log.Info("AggSender stopped")
 			return
 		}
+		if a.metrics != nil {
+			a.metrics.bucketDepth.Set(a.limiter.depth())
+		}
+		a.waitForEpochAlignment(ctx, &lastEpoch, &haveEpoch)
+		a.applyJitter(ctx)
+
+		if a.faultPicker != nil && a.faultPicker.Done() {
+			a.log.Info("fault scenario reached its iteration limit, AggSender stopped")
+			return
+		}
+
+		thereArePendingCerts := a.checkPendingCertificatesStatus(ctx)
+		if thereArePendingCerts {
+			a.log.Infof("Skipping because there are pending certificates")
+			if a.metrics != nil {
+				a.metrics.skippedTotal.Inc()
+			}
+			continue
+		}
+
+		effectiveScenarioID := scenarioID
+		if a.faultPicker != nil {
+			effectiveScenarioID = ScenarioID(a.faultPicker.Pick())
+		}
+		if overrideScenarioID := a.Scenario(); overrideScenarioID != ScenarioNone {
+			effectiveScenarioID = overrideScenarioID
+		}
+
+		signedCertificate, err := a.sendCertificate(ctx, emptyCert, addFakeBridge, storeCertificate, singleCert, effectiveScenarioID)
+		a.status.SetLastError(err)
+		if err != nil {
+			a.log.Error(err)
+			if a.metrics != nil {
+				a.metrics.failedTotal.Inc()
+			}
+			continue
+		}
+		if a.metrics != nil && signedCertificate != nil {
+			a.metrics.sentTotal.Inc()
+		}
 	}
 }
 
 // sendCertificate sends certificate for a network
-func (a *AggSender) sendCertificate(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool) (*agglayer.SignedCertificate, error) {
+func (a *AggSender) sendCertificate(
+	ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool, scenarioID ScenarioID,
+) (*agglayer.SignedCertificate, error) {
 	a.log.Infof("trying to send a new certificate...")
 
 	lastL2BlockSynced, err := a.l2Syncer.GetLastProcessedBlock(ctx)
@@ -220,6 +380,9 @@ func (a *AggSender) sendCertificate(ctx context.Context, emptyCert, addFakeBridg
 	if err != nil {
 		return nil, fmt.Errorf("error building certificate: %w", err)
 	}
+	if a.metrics != nil {
+		a.metrics.builtTotal.Inc()
+	}
 
 	if emptyCert {
 		log.Info("Removing bridges and claims from certificate to send the empty certificate")
@@ -227,21 +390,55 @@ func (a *AggSender) sendCertificate(ctx context.Context, emptyCert, addFakeBridg
 		certificate.ImportedBridgeExits = []*agglayer.ImportedBridgeExit{}
 	}
 
-	signedCertificate, err := a.signCertificate(certificate)
-	if err != nil {
-		return nil, fmt.Errorf("error signing certificate: %w", err)
+	if scenarioID != ScenarioNone {
+		if err := applyScenario(scenarioID, certificate); err != nil {
+			return nil, fmt.Errorf("error applying scenario %s: %w", scenarioID, err)
+		}
+		a.log.Warnf("scenario %s applied, certificate is deliberately malformed", scenarioID)
+	}
+
+	var signedCertificate *agglayer.SignedCertificate
+	if scenarioID == ScenarioForeignSigner {
+		foreignKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("error generating foreign-signer scenario key: %w", err)
+		}
+		signedCertificate, err = a.signCertificateWithKey(certificate, foreignKey)
+		if err != nil {
+			return nil, fmt.Errorf("error signing certificate: %w", err)
+		}
+	} else {
+		signedCertificate, err = a.signCertificate(ctx, certificate)
+		if err != nil {
+			return nil, fmt.Errorf("error signing certificate: %w", err)
+		}
 	}
 
-	a.saveCertificateToFile(signedCertificate)
+	a.saveScenarioCertificateToFile(signedCertificate, scenarioID, fromBlock, toBlock)
 	a.log.Infof("certificate ready to be send to AggLayer: %s", signedCertificate.Brief())
 	if a.cfg.DryRun {
 		a.log.Warn("dry run mode enabled, skipping sending certificate")
 		return signedCertificate, nil
 	}
-	certificateHash, err := a.aggLayerClient.SendCertificate(signedCertificate)
+	sendStart := time.Now()
+	certificateHash, err := a.transport.SendCertificate(signedCertificate)
+	if a.metrics != nil {
+		a.metrics.sendLatency.Observe(time.Since(sendStart).Seconds())
+	}
 	if err != nil {
+		if a.metrics != nil {
+			a.metrics.rejectionsByReason.WithLabelValues(spammer.ClassifyAgglayerError(err)).Inc()
+		}
+		if scenarioID != ScenarioNone {
+			a.log.Warnf("scenario %s reproduced an agglayer rejection: %v", scenarioID, err)
+		}
+		a.emitResult(signedCertificate, scenarioID, false, spammer.ClassifyAgglayerError(err), time.Since(sendStart))
 		return nil, fmt.Errorf("error sending certificate: %w", err)
 	}
+	a.emitResult(signedCertificate, scenarioID, true, "", time.Since(sendStart))
+	if scenarioID != ScenarioNone {
+		a.log.Warnf("scenario %s was accepted by the agglayer instead of being rejected", scenarioID)
+	}
 
 	a.log.Debugf("Certificate sent with hash: %s height: %d, cert: %s", certificateHash.String(), signedCertificate.Height, signedCertificate.Brief())
 
@@ -287,6 +484,10 @@ func (a *AggSender) sendCertificate(ctx context.Context, emptyCert, addFakeBridg
 // saveCertificateToStorage saves the certificate to the storage
 // it retries if it fails. if param retries == 0 it retries indefinitely
 func (a *AggSender) saveCertificateToStorage(ctx context.Context, cert types.CertificateInfo, maxRetries int) error {
+	if err := a.BackupCertificate(ctx, cert); err != nil {
+		return fmt.Errorf("error backing up certificate %s before saving it: %w", cert.ID(), err)
+	}
+
 	retries := 1
 	err := fmt.Errorf("initial_error")
 	for err != nil {
@@ -334,21 +535,43 @@ func (a *AggSender) limitCertSize(fullCert *types.CertificateBuildParams) (*type
 	}
 }
 
-// saveCertificate saves the certificate to a tmp file
-func (a *AggSender) saveCertificateToFile(signedCertificate *agglayer.SignedCertificate) {
+// saveScenarioCertificateToFile saves the certificate to a tmp file, tagging
+// the filename with scenarioID so a fuzz run's rejections are easy to tell
+// apart from each other and from normal certificates. It also records a
+// manifest.json entry for it, so ReplayCertificates can later resubmit the
+// directory's certificates in the order they were originally sent.
+func (a *AggSender) saveScenarioCertificateToFile(
+	signedCertificate *agglayer.SignedCertificate, scenarioID ScenarioID, fromBlock, toBlock uint64,
+) {
 	if signedCertificate == nil || a.cfg.SaveCertificatesToFilesPath == "" {
 		return
 	}
-	fn := fmt.Sprintf("%s/certificate_%04d-%07d.json",
-		a.cfg.SaveCertificatesToFilesPath, signedCertificate.Height, time.Now().Unix())
+	fileName := fmt.Sprintf("certificate_%04d-%07d.json", signedCertificate.Height, time.Now().Unix())
+	if scenarioID != ScenarioNone {
+		fileName = fmt.Sprintf("certificate_%04d-%07d_%s.json", signedCertificate.Height, time.Now().Unix(), scenarioID)
+	}
+	fn := fmt.Sprintf("%s/%s", a.cfg.SaveCertificatesToFilesPath, fileName)
 	a.log.Infof("saving certificate to file: %s", fn)
 	jsonData, err := json.MarshalIndent(signedCertificate, "", "  ")
 	if err != nil {
 		a.log.Errorf("error marshalling certificate: %w", err)
+		return
 	}
 
 	if err = os.WriteFile(fn, jsonData, 0644); err != nil { //nolint:gosec,mnd // we are writing to a tmp file
 		a.log.Errorf("error writing certificate to file: %w", err)
+		return
+	}
+
+	entry := ManifestEntry{
+		File:            fileName,
+		Height:          signedCertificate.Height,
+		CertificateHash: crypto.Keccak256Hash(jsonData),
+		FromBlock:       fromBlock,
+		ToBlock:         toBlock,
+	}
+	if err := appendManifestEntry(a.cfg.SaveCertificatesToFilesPath, entry); err != nil {
+		a.log.Errorf("error updating manifest for %s: %w", fn, err)
 	}
 }
 
@@ -688,17 +911,49 @@ func (a *AggSender) getImportedBridgeExits(
 	return importedBridgeExits, nil
 }
 
-// signCertificate signs a certificate with the sequencer key
-func (a *AggSender) signCertificate(certificate *agglayer.Certificate) (*agglayer.SignedCertificate, error) {
+// signCertificate signs a certificate through a.certSigner, whatever backend
+// it is wired to (a local key by default, or a remote Clef-style signer or
+// KMS/HSM set via SetSigner). ctx bounds the signer call so a hung remote
+// signer doesn't block the send loop forever.
+func (a *AggSender) signCertificate(ctx context.Context, certificate *agglayer.Certificate) (*agglayer.SignedCertificate, error) {
 	hashToSign := certificate.HashToSign()
 
-	sig, err := crypto.Sign(hashToSign.Bytes(), a.sequencerKey)
+	r, s, v, err := a.certSigner.SignHash(ctx, hashToSign)
 	if err != nil {
 		return nil, err
 	}
 
-	a.log.Infof("Signed certificate. sequencer address: %s. New local exit root: %s Hash signed: %s",
-		crypto.PubkeyToAddress(a.sequencerKey.PublicKey).String(),
+	a.audit.Log(ctx, audit.Event{
+		Type:             audit.CertificateSigned,
+		Height:           certificate.Height,
+		NewLocalExitRoot: common.BytesToHash(certificate.NewLocalExitRoot[:]).String(),
+		SignerAddress:    a.certSigner.Address().String(),
+		Detail:           fmt.Sprintf("hash signed: %s", hashToSign.String()),
+	})
+
+	return &agglayer.SignedCertificate{
+		Certificate: certificate,
+		Signature: &agglayer.Signature{
+			R:         common.Hash(r),
+			S:         common.Hash(s),
+			OddParity: v%2 == 1,
+		},
+	}, nil
+}
+
+// signCertificateWithKey signs a certificate directly with an arbitrary
+// key, bypassing a.certSigner, so the foreign-signer fuzz scenario can sign
+// with a key other than the sequencer's.
+func (a *AggSender) signCertificateWithKey(certificate *agglayer.Certificate, key *ecdsa.PrivateKey) (*agglayer.SignedCertificate, error) {
+	hashToSign := certificate.HashToSign()
+
+	sig, err := crypto.Sign(hashToSign.Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	a.log.Infof("Signed certificate. signer address: %s. New local exit root: %s Hash signed: %s",
+		crypto.PubkeyToAddress(key.PublicKey).String(),
 		common.BytesToHash(certificate.NewLocalExitRoot[:]).String(),
 		hashToSign.String(),
 	)
@@ -732,33 +987,130 @@ func (a *AggSender) checkPendingCertificatesStatus(ctx context.Context) bool {
 	a.log.Debugf("checkPendingCertificatesStatus num of pendingCertificates: %d", len(pendingCertificates))
 	thereArePendingCerts := false
 
+	lastSentCertificateInfo, err := a.storage.GetLastSentCertificate()
+	if err != nil {
+		a.log.Errorf("error getting last sent certificate: %w", err)
+		return true
+	}
+
 	for _, certificate := range pendingCertificates {
-		certificateHeader, err := a.aggLayerClient.GetCertificateHeader(certificate.CertificateID)
-		if err != nil {
-			a.log.Errorf("error getting certificate header of %s from agglayer: %w",
-				certificate.ID(), err)
-			return true
+		if lastSentCertificateInfo != nil && certificate.Height > lastSentCertificateInfo.Height {
+			// A reorg recovery (rewindLocalStateToAgglayer) can rewind the last-sent pointer below this row's height without
+			// deleting the row itself; agglayer no longer recognizes a
+			// certificate ID above its own rewound tip, so rechecking it would
+			// just fail forever. Skip it instead of counting it as pending.
+			a.log.Debugf("skipping stale pending certificate %s at height %d, above last sent height %d",
+				certificate.ID(), certificate.Height, lastSentCertificateInfo.Height)
+			continue
 		}
 
-		a.log.Debugf("aggLayerClient.GetCertificateHeader status [%s] of certificate %s  elapsed time:%s",
-			certificateHeader.Status,
-			certificateHeader.ID(),
-			certificate.ElapsedTimeSinceCreation())
+		if !a.retries.readyToRetry(certificate.CertificateID) {
+			// Backing off after a recoverable failure: the reconciliation
+			// loop owns retrying this one until its backoff elapses.
+			thereArePendingCerts = true
+			continue
+		}
 
-		if err := a.updateCertificateStatus(ctx, certificate, certificateHeader); err != nil {
-			a.log.Errorf("error updating certificate %s status in storage: %w", certificateHeader.String(), err)
+		closed, err := a.recheckCertificate(ctx, certificate)
+		if err != nil {
+			a.log.Errorf("error rechecking certificate %s status: %v", certificate.ID(), err)
 			return true
 		}
-
-		if !certificate.IsClosed() {
-			a.log.Infof("certificate %s is still pending, elapsed time:%s ",
-				certificateHeader.ID(), certificate.ElapsedTimeSinceCreation())
+		if !closed {
 			thereArePendingCerts = true
 		}
 	}
 	return thereArePendingCerts
 }
 
+// recheckCertificate asks agglayer for certificate's current status and
+// updates local storage if it changed, returning whether the certificate is
+// now closed. A recoverable error (network blip, overloaded agglayer,
+// contended local DB) is recorded in a.retries and reported as "not closed
+// yet" instead of returned, so one flaky certificate doesn't stall the rest
+// of the scan; a fatal error (invalid signature, height inconsistency) is
+// returned to the caller.
+func (a *AggSender) recheckCertificate(ctx context.Context, certificate *types.CertificateInfo) (closed bool, err error) {
+	certificateHeader, err := a.aggLayerClient.GetCertificateHeader(certificate.CertificateID)
+	if err != nil {
+		classified := classifyStatusErr(err)
+		if isRecoverable(classified) {
+			a.retries.recordFailure(certificate.CertificateID)
+			a.log.Warnf("recoverable error getting certificate header of %s from agglayer, will retry: %v",
+				certificate.ID(), classified)
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting certificate header of %s from agglayer: %w", certificate.ID(), classified)
+	}
+
+	a.log.Debugf("aggLayerClient.GetCertificateHeader status [%s] of certificate %s  elapsed time:%s",
+		certificateHeader.Status,
+		certificateHeader.ID(),
+		certificate.ElapsedTimeSinceCreation())
+
+	if err := a.updateCertificateStatus(ctx, certificate, certificateHeader); err != nil {
+		classified := classifyStatusErr(err)
+		if isRecoverable(classified) {
+			a.retries.recordFailure(certificate.CertificateID)
+			a.log.Warnf("recoverable error updating certificate %s status in storage, will retry: %v",
+				certificateHeader.String(), classified)
+			return false, nil
+		}
+		return false, fmt.Errorf("error updating certificate %s status in storage: %w", certificateHeader.String(), classified)
+	}
+
+	a.retries.clear(certificate.CertificateID)
+
+	if !certificate.IsClosed() {
+		a.log.Infof("certificate %s is still pending, elapsed time:%s ",
+			certificateHeader.ID(), certificate.ElapsedTimeSinceCreation())
+		return false, nil
+	}
+	return true, nil
+}
+
+// reconciliationLoop periodically re-drives pending certificates whose last
+// status check failed with a recoverable error, honoring each one's
+// exponential backoff. It runs independently of sendCertificates' own
+// pending-certificate check, so retries keep happening on a steady cadence
+// even when the send loop's rate limit makes it tick rarely.
+func (a *AggSender) reconciliationLoop(ctx context.Context) {
+	ticker := time.NewTicker(a.reconciliationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.reconcilePendingRetries(ctx)
+		}
+	}
+}
+
+// reconcilePendingRetries re-checks every pending certificate whose backoff
+// has elapsed.
+func (a *AggSender) reconcilePendingRetries(ctx context.Context) {
+	due := a.retries.due(time.Now())
+	if len(due) == 0 {
+		return
+	}
+
+	pendingCertificates, err := a.storage.GetCertificatesByStatus(agglayer.NonSettledStatuses)
+	if err != nil {
+		a.log.Errorf("reconciliation: error getting pending certificates: %v", err)
+		return
+	}
+
+	for _, certificate := range pendingCertificates {
+		if !due[certificate.CertificateID] {
+			continue
+		}
+		if _, err := a.recheckCertificate(ctx, certificate); err != nil {
+			a.log.Errorf("reconciliation: %v", err)
+		}
+	}
+}
+
 // updateCertificate updates the certificate status in the storage
 func (a *AggSender) updateCertificateStatus(ctx context.Context,
 	localCert *types.CertificateInfo,
@@ -766,14 +1118,28 @@ func (a *AggSender) updateCertificateStatus(ctx context.Context,
 	if localCert.Status == agglayerCert.Status {
 		return nil
 	}
-	a.log.Infof("certificate %s changed status from [%s] to [%s] elapsed time: %s full_cert (agglayer): %s",
-		localCert.ID(), localCert.Status, agglayerCert.Status, localCert.ElapsedTimeSinceCreation(),
-		agglayerCert.String())
+	a.audit.Log(ctx, audit.Event{
+		Type:          audit.CertificateStatusChanged,
+		CertificateID: fmt.Sprintf("%s", localCert.ID()),
+		Height:        localCert.Height,
+		FromStatus:    localCert.Status.String(),
+		ToStatus:      agglayerCert.Status.String(),
+		ElapsedTime:   localCert.ElapsedTimeSinceCreation().String(),
+	})
 
 	// That is a strange situation
 	if agglayerCert.Status.IsOpen() && localCert.Status.IsClosed() {
-		a.log.Warnf("certificate %s is reopened! from [%s] to [%s]",
-			localCert.ID(), localCert.Status, agglayerCert.Status)
+		a.audit.Log(ctx, audit.Event{
+			Type:          audit.CertificateReopened,
+			CertificateID: fmt.Sprintf("%s", localCert.ID()),
+			Height:        localCert.Height,
+			FromStatus:    localCert.Status.String(),
+			ToStatus:      agglayerCert.Status.String(),
+		})
+	}
+
+	if err := a.BackupCertificate(ctx, *localCert); err != nil {
+		return fmt.Errorf("error backing up certificate %s before status transition: %w", localCert.ID(), err)
 	}
 
 	localCert.Status = agglayerCert.Status
@@ -817,17 +1183,40 @@ func (a *AggSender) checkLastCertificateFromAgglayer(ctx context.Context) error
 	// CASE 2.1: certificate in storage but not in agglayer
 	// this is a non-sense, so throw an error
 	if localLastCert != nil && aggLayerLastCert == nil {
+		a.audit.Log(ctx, audit.Event{
+			Type:          audit.RecoveryMismatch,
+			CertificateID: fmt.Sprintf("%s", localLastCert.ID()),
+			Height:        localLastCert.Height,
+			Detail:        "certificate exists in local storage but agglayer has none",
+		})
 		return fmt.Errorf("recovery: certificate exists in storage but not in agglayer. Inconsistency")
 	}
 	// CASE 3.1: the certificate on the agglayer has less height than the one stored in the local storage
 	if aggLayerLastCert.Height < localLastCert.Height {
-		return fmt.Errorf("recovery: the last certificate in the agglayer has less height (%d) "+
-			"than the one in the local storage (%d)", aggLayerLastCert.Height, localLastCert.Height)
+		if !a.allowReorgRecovery {
+			a.audit.Log(ctx, audit.Event{
+				Type:          audit.ReorgDetected,
+				CertificateID: fmt.Sprintf("%s", localLastCert.ID()),
+				Height:        localLastCert.Height,
+				Detail: fmt.Sprintf("agglayer height %d < local height %d and AllowReorgRecovery is disabled",
+					aggLayerLastCert.Height, localLastCert.Height),
+			})
+			return fmt.Errorf("recovery: the last certificate in the agglayer has less height (%d) "+
+				"than the one in the local storage (%d)", aggLayerLastCert.Height, localLastCert.Height)
+		}
+		localLastCert, err = a.rewindLocalStateToAgglayer(ctx, aggLayerLastCert, localLastCert)
+		if err != nil {
+			return err
+		}
 	}
 	// CASE 3.2: aggsender stopped between sending to agglayer and storing to the local storage
 	if aggLayerLastCert.Height == localLastCert.Height+1 {
 		a.log.Infof("recovery: AggLayer has the next cert (height: %d), so is a recovery case: storing cert: %s",
 			aggLayerLastCert.Height, aggLayerLastCert.String())
+		if err := a.BackupCertificate(ctx, *localLastCert); err != nil {
+			return fmt.Errorf("recovery: error backing up local certificate %s before overwriting it with "+
+				"agglayer's: %w", localLastCert.ID(), err)
+		}
 		// we need to store the certificate in the local storage.
 		localLastCert, err = a.updateLocalStorageWithAggLayerCert(ctx, aggLayerLastCert)
 		if err != nil {
@@ -839,9 +1228,22 @@ func (a *AggSender) checkLastCertificateFromAgglayer(ctx context.Context) error
 	// note: we don't need to check individual fields of the certificate
 	// because CertificateID is a hash of all the fields
 	if localLastCert.CertificateID != aggLayerLastCert.CertificateID {
-		a.log.Errorf("recovery: Local certificate:\n %s \n is different from agglayer certificate:\n %s",
-			localLastCert.String(), aggLayerLastCert.String())
-		return fmt.Errorf("recovery: mismatch between local and agglayer certificates")
+		if !a.allowReorgRecovery {
+			a.audit.Log(ctx, audit.Event{
+				Type:          audit.RecoveryMismatch,
+				CertificateID: fmt.Sprintf("%s", localLastCert.ID()),
+				Height:        localLastCert.Height,
+				Detail: fmt.Sprintf("local certificate %s differs from agglayer certificate %s and AllowReorgRecovery is disabled",
+					localLastCert.CertificateID, aggLayerLastCert.CertificateID),
+			})
+			a.log.Errorf("recovery: Local certificate:\n %s \n is different from agglayer certificate:\n %s",
+				localLastCert.String(), aggLayerLastCert.String())
+			return fmt.Errorf("recovery: mismatch between local and agglayer certificates")
+		}
+		localLastCert, err = a.rollbackToCommonAncestor(ctx, aggLayerLastCert, localLastCert)
+		if err != nil {
+			return err
+		}
 	}
 	// CASE 5: AggSender and AggLayer are at same page
 	// just update status
@@ -859,7 +1261,14 @@ func (a *AggSender) checkLastCertificateFromAgglayer(ctx context.Context) error
 func (a *AggSender) updateLocalStorageWithAggLayerCert(ctx context.Context,
 	aggLayerCert *agglayer.CertificateHeader) (*types.CertificateInfo, error) {
 	certInfo := NewCertificateInfoFromAgglayerCertHeader(aggLayerCert)
-	a.log.Infof("setting initial certificate from AggLayer: %s", certInfo.String())
+	a.audit.Log(ctx, audit.Event{
+		Type:             audit.CertificateStatusChanged,
+		CertificateID:    fmt.Sprintf("%s", certInfo.ID()),
+		Height:           certInfo.Height,
+		NewLocalExitRoot: common.BytesToHash(certInfo.NewLocalExitRoot[:]).String(),
+		ToStatus:         certInfo.Status.String(),
+		Detail:           "local storage set from agglayer's certificate",
+	})
 	return certInfo, a.storage.SaveLastSentCertificate(ctx, *certInfo)
 }
 
@@ -0,0 +1,56 @@
+package aggsender
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0xPolygon/cdk/aggsender/types"
+)
+
+// Coordinator fans Start out across several AggSenders, each bound to its
+// own NetworkID via the usual New constructor, and aggregates their Info().
+// This lets a single process simulate load from multiple rollups at once,
+// without having to teach AggSender itself to juggle more than one
+// l2Syncer/certSigner/storage/Tree: every registered AggSender keeps
+// running its existing single-network loop, Coordinator just owns the set
+// of them.
+type Coordinator struct {
+	mu      sync.RWMutex
+	senders map[uint32]*AggSender
+}
+
+// NewCoordinator returns an empty Coordinator ready to have AggSenders
+// registered with Add.
+func NewCoordinator() *Coordinator {
+	return &Coordinator{senders: make(map[uint32]*AggSender)}
+}
+
+// Add registers sender under networkID, replacing any previous AggSender
+// registered for that network.
+func (c *Coordinator) Add(networkID uint32, sender *AggSender) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.senders[networkID] = sender
+}
+
+// Start runs every registered AggSender's Start loop in its own goroutine,
+// with the same flags and fuzz scenario applied to all of them. It returns
+// once every goroutine has been launched; it does not wait for them to stop.
+func (c *Coordinator) Start(ctx context.Context, emptyCert, addFakeBridge, storeCertificate, singleCert bool, scenarioID ScenarioID) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, sender := range c.senders {
+		go sender.Start(ctx, emptyCert, addFakeBridge, storeCertificate, singleCert, scenarioID)
+	}
+}
+
+// Info returns every registered AggSender's Info(), keyed by network id.
+func (c *Coordinator) Info() map[uint32]types.AggsenderInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	info := make(map[uint32]types.AggsenderInfo, len(c.senders))
+	for networkID, sender := range c.senders {
+		info[networkID] = sender.Info()
+	}
+	return info
+}
@@ -0,0 +1,161 @@
+package aggsender
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/aggsender/db"
+	"github.com/0xPolygon/cdk/aggsender/types"
+	cdkdb "github.com/0xPolygon/cdk/db"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const createCertificateHistoryTableSQL = `
+CREATE TABLE IF NOT EXISTS certificate_history (
+	certificate_id            TEXT    NOT NULL,
+	updated_at                INTEGER NOT NULL,
+	height                    INTEGER NOT NULL,
+	retry_count               INTEGER NOT NULL,
+	new_local_exit_root       TEXT    NOT NULL,
+	previous_local_exit_root  TEXT,
+	from_block                INTEGER NOT NULL,
+	to_block                  INTEGER NOT NULL,
+	created_at                INTEGER NOT NULL,
+	status                    INTEGER NOT NULL,
+	signed_certificate        TEXT,
+	PRIMARY KEY (certificate_id, updated_at)
+);`
+
+// CertificateHistory backs up every CertificateInfo snapshot taken just
+// before it is overwritten in db.AggSenderStorage, so an unintended
+// transition (most notably one made by reorg recovery, see SetAllowReorgRecovery)
+// can be inspected and reverted with Restore. cfg.KeepCertificatesHistory
+// already tells db.AggSenderStorage to keep its own internal history, but
+// that interface exposes no way to read it back out or restore from it, so
+// CertificateHistory keeps its own copy in a dedicated connection to the
+// aggsender storage's own SQLite file - this mirrors how ConnectTree opens
+// its own connection to the bridge database for the exit tree's "root" table.
+type CertificateHistory struct {
+	db *sql.DB
+}
+
+// ConnectCertificateHistory opens (and migrates) certificate_history in
+// storagePath, the same SQLite file db.AggSenderStorage uses for its own
+// tables.
+func ConnectCertificateHistory(storagePath string) (*CertificateHistory, error) {
+	sqlDB, err := cdkdb.NewSQLiteDB(storagePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sqlDB.Exec(createCertificateHistoryTableSQL); err != nil {
+		return nil, fmt.Errorf("error creating certificate_history table: %w", err)
+	}
+	return &CertificateHistory{db: sqlDB}, nil
+}
+
+// Backup snapshots cert - including its raw signed JSON, if any - into
+// certificate_history, keyed by (CertificateID, UpdatedAt) so every
+// transition a certificate goes through is told apart.
+func (h *CertificateHistory) Backup(ctx context.Context, cert types.CertificateInfo) error {
+	var previousLocalExitRoot *string
+	if cert.PreviousLocalExitRoot != nil {
+		s := cert.PreviousLocalExitRoot.String()
+		previousLocalExitRoot = &s
+	}
+
+	_, err := h.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO certificate_history
+			(certificate_id, updated_at, height, retry_count, new_local_exit_root,
+			 previous_local_exit_root, from_block, to_block, created_at, status, signed_certificate)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		cert.CertificateID.String(),
+		cert.UpdatedAt,
+		cert.Height,
+		cert.RetryCount,
+		common.BytesToHash(cert.NewLocalExitRoot[:]).String(),
+		previousLocalExitRoot,
+		cert.FromBlock,
+		cert.ToBlock,
+		cert.CreatedAt,
+		int(cert.Status),
+		cert.SignedCertificate,
+	)
+	if err != nil {
+		return fmt.Errorf("error backing up certificate %s: %w", cert.ID(), err)
+	}
+	return nil
+}
+
+// Restore looks up the certificate_history snapshot for (id, updatedAt) and
+// re-applies it as id's current state in storage, letting an operator revert
+// an unintended transition back to exactly what was recorded just before it
+// happened.
+func (h *CertificateHistory) Restore(ctx context.Context, storage db.AggSenderStorage,
+	id common.Hash, updatedAt uint32) (*types.CertificateInfo, error) {
+	row := h.db.QueryRowContext(ctx,
+		`SELECT height, retry_count, new_local_exit_root, previous_local_exit_root,
+			from_block, to_block, created_at, status, signed_certificate
+		 FROM certificate_history WHERE certificate_id = $1 AND updated_at = $2`,
+		id.String(), updatedAt,
+	)
+
+	var (
+		height, fromBlock, toBlock uint64
+		retryCount                 int
+		status                     int
+		createdAt                  uint32
+		newLocalExitRoot           string
+		previousLocalExitRoot      sql.NullString
+		signedCertificate          sql.NullString
+	)
+	if err := row.Scan(&height, &retryCount, &newLocalExitRoot, &previousLocalExitRoot,
+		&fromBlock, &toBlock, &createdAt, &status, &signedCertificate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no certificate_history snapshot for certificate %s at updated_at %d", id, updatedAt)
+		}
+		return nil, fmt.Errorf("error reading certificate_history snapshot for certificate %s: %w", id, err)
+	}
+
+	restored := types.CertificateInfo{
+		Height:            height,
+		RetryCount:        retryCount,
+		CertificateID:     id,
+		NewLocalExitRoot:  common.HexToHash(newLocalExitRoot),
+		FromBlock:         fromBlock,
+		ToBlock:           toBlock,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+		Status:            agglayer.CertificateStatus(status),
+		SignedCertificate: signedCertificate.String,
+	}
+	if previousLocalExitRoot.Valid {
+		prevLER := common.HexToHash(previousLocalExitRoot.String)
+		restored.PreviousLocalExitRoot = &prevLER
+	}
+
+	if err := storage.UpdateCertificate(ctx, restored); err != nil {
+		return nil, fmt.Errorf("error restoring certificate %s to its snapshot at updated_at %d: %w", id, updatedAt, err)
+	}
+
+	return &restored, nil
+}
+
+// BackupCertificate snapshots cert into certificate_history before a
+// mutating UpdateCertificate or SaveLastSentCertificate call.
+func (a *AggSender) BackupCertificate(ctx context.Context, cert types.CertificateInfo) error {
+	return a.history.Backup(ctx, cert)
+}
+
+// RestoreCertificate rolls id back to the certificate_history snapshot
+// recorded at updatedAt, re-applying it through a.storage.
+func (a *AggSender) RestoreCertificate(ctx context.Context, id common.Hash, updatedAt uint32) (*types.CertificateInfo, error) {
+	restored, err := a.history.Restore(ctx, a.storage, id, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	a.log.Warnf("restored certificate %s to its snapshot at updated_at %d", id, updatedAt)
+	return restored, nil
+}
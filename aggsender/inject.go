@@ -0,0 +1,197 @@
+package aggsender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/aggsender/types"
+	"github.com/0xPolygon/cdk/bridgesync"
+	cdkdb "github.com/0xPolygon/cdk/db"
+	treeTypes "github.com/0xPolygon/cdk/tree/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// injectedState holds bridge/claim data fed in out of band through the rpc
+// package, plus the scenario currently applied to the ticker loop. It has
+// its own mutex because it is written from RPC handler goroutines
+// concurrently with the ticker loop reading the scenario.
+type injectedState struct {
+	mu       sync.Mutex
+	bridges  []bridgesync.Bridge
+	claims   []bridgesync.Claim
+	scenario ScenarioID
+}
+
+// SetScenario changes the fuzz scenario applied to every future certificate,
+// overriding whatever scenario was passed to Start. Intended for the rpc
+// package's agg_setScenario, so a running AggSender can be retargeted
+// without restarting it.
+func (a *AggSender) SetScenario(scenarioID ScenarioID) {
+	a.injected.mu.Lock()
+	defer a.injected.mu.Unlock()
+	a.injected.scenario = scenarioID
+}
+
+// Scenario returns the scenario last set via SetScenario, or ScenarioNone.
+func (a *AggSender) Scenario() ScenarioID {
+	a.injected.mu.Lock()
+	defer a.injected.mu.Unlock()
+	return a.injected.scenario
+}
+
+// InjectBridge appends a synthetic bridge leaf to the AppendOnlyTree and
+// queues it to be picked up by the next ForceSendCertificate call. Intended
+// for the rpc package's agg_injectBridge, to drive deterministic scenarios
+// without waiting for the L2 syncer to observe a real bridge event.
+func (a *AggSender) InjectBridge(ctx context.Context, bridge bridgesync.Bridge) (treeTypes.Root, error) {
+	tx, err := cdkdb.NewTx(ctx, a.BridgeDatabase)
+	if err != nil {
+		return treeTypes.Root{}, err
+	}
+
+	if err := a.Tree.AddLeaf(tx, bridge.BlockNum, bridge.BlockPos, treeTypes.Leaf{
+		Index: bridge.DepositCount,
+		Hash:  bridge.Hash(),
+	}); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			a.log.Errorf("error rolling back the transaction. Err: %v", errRollback)
+			return treeTypes.Root{}, errRollback
+		}
+		return treeTypes.Root{}, fmt.Errorf("error adding injected bridge leaf: %w", err)
+	}
+
+	root, err := a.GetRootByIndex(ctx, bridge.DepositCount, tx)
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			a.log.Errorf("error rolling back the transaction. Err: %v", errRollback)
+			return treeTypes.Root{}, errRollback
+		}
+		return treeTypes.Root{}, fmt.Errorf("error getting root for injected bridge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return treeTypes.Root{}, fmt.Errorf("error committing injected bridge: %w", err)
+	}
+
+	a.injected.mu.Lock()
+	a.injected.bridges = append(a.injected.bridges, bridge)
+	a.injected.mu.Unlock()
+
+	return root, nil
+}
+
+// InjectClaim queues a synthetic claim to be picked up by the next
+// ForceSendCertificate call. Intended for the rpc package's
+// agg_injectClaim.
+func (a *AggSender) InjectClaim(claim bridgesync.Claim) {
+	a.injected.mu.Lock()
+	defer a.injected.mu.Unlock()
+	a.injected.claims = append(a.injected.claims, claim)
+}
+
+// CertificateAtHeight returns the certificate previously sent at height, if
+// any. Intended for the rpc package's agg_getCertificate.
+func (a *AggSender) CertificateAtHeight(height uint64) (*types.CertificateInfo, error) {
+	info, err := a.storage.GetCertificateByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting certificate at height %d: %w", height, err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("no certificate known for height %d", height)
+	}
+	return info, nil
+}
+
+// ForceSendCertificate immediately builds, signs and sends a certificate
+// from whatever bridges/claims have been queued via InjectBridge and
+// InjectClaim, out of band from the usual ticker loop, applying the
+// scenario currently set via SetScenario (if any). Intended for the rpc
+// package's agg_forceSendCertificate.
+func (a *AggSender) ForceSendCertificate(ctx context.Context, storeCertificate bool) (*agglayer.SignedCertificate, error) {
+	a.injected.mu.Lock()
+	bridges := a.injected.bridges
+	claims := a.injected.claims
+	a.injected.bridges = nil
+	a.injected.claims = nil
+	a.injected.mu.Unlock()
+
+	if len(bridges) == 0 && len(claims) == 0 {
+		return nil, fmt.Errorf("no injected bridges or claims to build a certificate from")
+	}
+
+	lastSentCertificateInfo, err := a.storage.GetLastSentCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	certificateParams := &types.CertificateBuildParams{
+		Bridges:   bridges,
+		Claims:    claims,
+		CreatedAt: uint32(time.Now().UTC().Unix()),
+	}
+
+	certificate, err := a.buildCertificate(ctx, certificateParams, lastSentCertificateInfo, false)
+	if err != nil {
+		return nil, fmt.Errorf("error building certificate from injected data: %w", err)
+	}
+
+	scenarioID := a.Scenario()
+	if scenarioID != ScenarioNone {
+		if err := applyScenario(scenarioID, certificate); err != nil {
+			return nil, fmt.Errorf("error applying scenario %s: %w", scenarioID, err)
+		}
+		a.log.Warnf("scenario %s applied, certificate is deliberately malformed", scenarioID)
+	}
+
+	var signedCertificate *agglayer.SignedCertificate
+	if scenarioID == ScenarioForeignSigner {
+		foreignKey, keyErr := crypto.GenerateKey()
+		if keyErr != nil {
+			return nil, fmt.Errorf("error generating foreign-signer scenario key: %w", keyErr)
+		}
+		signedCertificate, err = a.signCertificateWithKey(certificate, foreignKey)
+	} else {
+		signedCertificate, err = a.signCertificate(ctx, certificate)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error signing certificate: %w", err)
+	}
+
+	a.saveScenarioCertificateToFile(signedCertificate, scenarioID, 0, 0)
+
+	certificateHash, err := a.transport.SendCertificate(signedCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("error sending certificate: %w", err)
+	}
+	a.log.Infof("certificate forced via rpc sent successfully, height: %d, hash: %s",
+		certificate.Height, certificateHash.String())
+
+	if !storeCertificate {
+		return signedCertificate, nil
+	}
+
+	raw, err := json.Marshal(signedCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling signed certificate: %w", err)
+	}
+	prevLER := common.BytesToHash(certificate.PrevLocalExitRoot[:])
+	certInfo := types.CertificateInfo{
+		Height:                certificate.Height,
+		CertificateID:         certificateHash,
+		NewLocalExitRoot:      certificate.NewLocalExitRoot,
+		PreviousLocalExitRoot: &prevLER,
+		CreatedAt:             certificateParams.CreatedAt,
+		UpdatedAt:             certificateParams.CreatedAt,
+		SignedCertificate:     string(raw),
+	}
+	if err := a.saveCertificateToStorage(ctx, certInfo, a.cfg.MaxRetriesStoreCertificate); err != nil {
+		return nil, fmt.Errorf("error storing forced certificate %s: %w", certInfo.String(), err)
+	}
+
+	return signedCertificate, nil
+}
@@ -0,0 +1,63 @@
+package aggsender
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RateMetrics holds the Prometheus collectors instrumenting sendCertificates'
+// token-bucket scheduler and sendCertificate's build/send pipeline, so a run
+// can be compared under bursty vs. smooth load patterns and so rejections
+// can be broken down by why the agglayer refused a certificate.
+type RateMetrics struct {
+	sentTotal    prometheus.Counter
+	failedTotal  prometheus.Counter
+	skippedTotal prometheus.Counter
+	bucketDepth  prometheus.Gauge
+
+	builtTotal         prometheus.Counter
+	sendLatency        prometheus.Histogram
+	rejectionsByReason *prometheus.CounterVec
+}
+
+// NewRateMetrics registers the sendCertificates scheduler collectors on reg.
+func NewRateMetrics(reg prometheus.Registerer) *RateMetrics {
+	factory := promauto.With(reg)
+	return &RateMetrics{
+		sentTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "aggsender_certs_sent_total",
+			Help: "Total number of certificates sent successfully by the scheduler.",
+		}),
+		failedTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "aggsender_certs_failed_total",
+			Help: "Total number of certificate send attempts that errored.",
+		}),
+		skippedTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "aggsender_certs_skipped_total",
+			Help: "Total number of scheduler ticks skipped because a certificate was already pending.",
+		}),
+		bucketDepth: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "aggsender_rate_limiter_bucket_depth",
+			Help: "Current number of tokens available in the certificate-send rate limiter's bucket.",
+		}),
+		builtTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "aggsender_certs_built_total",
+			Help: "Total number of certificates successfully built, before signing and sending.",
+		}),
+		sendLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "aggsender_send_latency_seconds",
+			Help: "Latency of transport.CertificateTransport.SendCertificate calls.",
+		}),
+		rejectionsByReason: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "aggsender_rejections_total",
+			Help: "Total number of certificate sends that errored, labeled by classified reason.",
+		}, []string{"reason"}),
+	}
+}
+
+// SetRateMetrics attaches Prometheus collectors to the sendCertificates
+// scheduler. Intended to be wired to the same registry the spammer package's
+// metrics server serves.
+func (a *AggSender) SetRateMetrics(m *RateMetrics) {
+	a.metrics = m
+}
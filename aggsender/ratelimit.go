@@ -0,0 +1,136 @@
+package aggsender
+
+import (
+	"context"
+	mathrand "math/rand/v2"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCertsPerSecond = 1
+	defaultBurst          = 1
+)
+
+// tokenBucket is a minimal token-bucket rate limiter whose current depth can
+// be observed, unlike golang.org/x/time/rate.Limiter which keeps that state
+// private. It backs sendCertificates' scheduler so a fixed 1 Hz probe can be
+// replaced with a configurable, burstable rate.
+type tokenBucket struct {
+	mu            sync.Mutex
+	tokens        float64
+	burst         float64
+	ratePerSecond float64
+	lastRefill    time.Time
+}
+
+// newTokenBucket returns a tokenBucket starting full, allowing ratePerSecond
+// tokens/sec to accrue up to burst. ratePerSecond <= 0 means unlimited.
+func newTokenBucket(ratePerSecond float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		tokens:        float64(burst),
+		burst:         float64(burst),
+		ratePerSecond: ratePerSecond,
+		lastRefill:    time.Now(),
+	}
+}
+
+// wait blocks until a token is available, or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		b.refillLocked()
+		if b.ratePerSecond <= 0 || b.tokens >= 1 {
+			if b.tokens >= 1 {
+				b.tokens--
+			}
+			b.mu.Unlock()
+			return nil
+		}
+		untilNextToken := time.Duration((1 - b.tokens) / b.ratePerSecond * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(untilNextToken):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// depth returns the current number of tokens available in the bucket.
+func (b *tokenBucket) depth() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refillLocked()
+	return b.tokens
+}
+
+func (b *tokenBucket) refillLocked() {
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+	if b.ratePerSecond <= 0 {
+		return
+	}
+	b.tokens += elapsed * b.ratePerSecond
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// SetRateLimit configures the token-bucket scheduler driving
+// sendCertificates: certsPerSecond is the steady-state rate (<= 0 means
+// unlimited) and burst is how many certificates can be sent back-to-back
+// before that rate applies. jitter adds a random extra delay, uniform in
+// [0, jitter), after each token is granted, so a run of certificates isn't
+// perfectly periodic - real sequencers rarely emit on a fixed clock. The
+// first token after a newly announced epoch gets a second such draw, from
+// waitForEpochAlignment.
+func (a *AggSender) SetRateLimit(certsPerSecond float64, burst int, jitter time.Duration) {
+	a.limiter = newTokenBucket(certsPerSecond, burst)
+	a.jitterMax = jitter
+}
+
+// applyJitter sleeps a random duration in [0, a.jitterMax), or returns early
+// if ctx is done.
+func (a *AggSender) applyJitter(ctx context.Context) {
+	if a.jitterMax <= 0 {
+		return
+	}
+	delay := time.Duration(mathrand.Int64N(int64(a.jitterMax)))
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+	}
+}
+
+// waitForEpochAlignment drains a.epochEvents for the newest epoch already
+// announced by a.epochNotifier. It never blocks waiting for one: a notifier
+// that hasn't fired yet (or doesn't support Subscribe at all, in which case
+// a.epochEvents is nil) leaves sendCertificates to rate-only pacing instead
+// of stalling the loop. The first time a given epoch is observed, it applies
+// the configured jitter, so emission lands somewhere inside that epoch
+// rather than right on its boundary - real sequencers rarely emit exactly
+// on the epoch tick either. lastEpoch/haveEpoch are owned by the caller's
+// loop so repeated calls can tell a still-current epoch from a new one.
+func (a *AggSender) waitForEpochAlignment(ctx context.Context, lastEpoch *uint64, haveEpoch *bool) {
+	if a.epochEvents == nil {
+		return
+	}
+	for {
+		select {
+		case event := <-a.epochEvents:
+			if !*haveEpoch || event.Epoch != *lastEpoch {
+				*lastEpoch = event.Epoch
+				*haveEpoch = true
+				a.applyJitter(ctx)
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package aggsender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/aggsender/types"
+
+	"github.com/ARR552/agglayer_certificate_spammer/audit"
+)
+
+// rewindLocalStateToAgglayer recovers from CASE 3.1 in
+// checkLastCertificateFromAgglayer (agglayer's last known certificate has a
+// lower height than the local one), which can happen after an L1 reorg
+// erases certificates agglayer had previously accepted. It rewinds the local
+// "last sent certificate" pointer down to whatever agglayer still knows
+// about, so the send loop resumes from agglayer's real height (via
+// getLastSentBlockAndRetryCount, which reads this pointer) instead of
+// getting stuck retrying a height agglayer no longer recognizes.
+//
+// This is a best-effort rewind, not a full one: db.AggSenderStorage and
+// tree.AppendOnlyTree are both external stores that only expose
+// point-lookups and upserts (no delete-by-height, and no mapping from a
+// certificate height back to an exit-tree leaf position), so the stale
+// per-height rows and tree leaves left above agglayer's height cannot be
+// purged here. GetLastSentCertificate and getLastSentBlockAndRetryCount do
+// drive off the pointer this function corrects, but checkPendingCertificatesStatus
+// reads GetCertificatesByStatus(NonSettledStatuses) directly and would
+// otherwise keep rechecking those stale rows against certificate IDs
+// agglayer no longer recognizes; it skips any row above the rewound height
+// instead. The two stores also live in separate SQLite databases
+// (cfg.StoragePath vs bridgeDB), so a single cdkdb.Txer spanning both isn't
+// possible; this function performs two separate writes, a
+// certificate_history backup of the local certificate and then the pointer
+// rewind, which is a single call.
+func (a *AggSender) rewindLocalStateToAgglayer(ctx context.Context,
+	aggLayerLastCert *agglayer.CertificateHeader, localLastCert *types.CertificateInfo) (*types.CertificateInfo, error) {
+	a.audit.Log(ctx, audit.Event{
+		Type:   audit.ReorgDetected,
+		Height: localLastCert.Height,
+		Detail: fmt.Sprintf("agglayer height %d < local height %d, rewinding to match agglayer",
+			aggLayerLastCert.Height, localLastCert.Height),
+	})
+
+	if err := a.BackupCertificate(ctx, *localLastCert); err != nil {
+		return nil, fmt.Errorf("recovery: error backing up local certificate %s before rewinding: %w", localLastCert.ID(), err)
+	}
+
+	rewound, err := a.updateLocalStorageWithAggLayerCert(ctx, aggLayerLastCert)
+	if err != nil {
+		return nil, fmt.Errorf("recovery: error rewinding local storage to agglayer height %d: %w",
+			aggLayerLastCert.Height, err)
+	}
+
+	a.audit.Log(ctx, audit.Event{
+		Type:   audit.ReorgDetected,
+		Height: rewound.Height,
+		Detail: "local last-sent pointer now matches agglayer",
+	})
+
+	return rewound, nil
+}
+
+// rollbackToCommonAncestor recovers from CASE 4 in
+// checkLastCertificateFromAgglayer (agglayer and local storage agree on the
+// last certificate's height but not its CertificateID). It compares each
+// side's PreviousLocalExitRoot: if they match, the fork is isolated to this
+// one height and it is safe to roll local state forward to agglayer's
+// certificate. If they don't match, the fork goes back further, and this
+// function cannot walk back any more than one height: agglayer.AgglayerClientInterface
+// only exposes GetCertificateHeader (by ID) and GetLatestKnownCertificateHeader
+// (the tip), with no by-height lookup to fetch its older certificates, so
+// the true common ancestor can't be located from here and this is reported
+// as an error for operator intervention.
+func (a *AggSender) rollbackToCommonAncestor(ctx context.Context,
+	aggLayerLastCert *agglayer.CertificateHeader, localLastCert *types.CertificateInfo) (*types.CertificateInfo, error) {
+	a.audit.Log(ctx, audit.Event{
+		Type:          audit.RecoveryMismatch,
+		CertificateID: fmt.Sprintf("%s", localLastCert.ID()),
+		Height:        localLastCert.Height,
+		Detail: fmt.Sprintf("local certificate %s differs from agglayer certificate %s at height %d",
+			localLastCert.CertificateID, aggLayerLastCert.CertificateID, localLastCert.Height),
+	})
+
+	localPrev := localLastCert.PreviousLocalExitRoot
+	aggPrev := aggLayerLastCert.PreviousLocalExitRoot
+	if localPrev == nil || aggPrev == nil || *localPrev != *aggPrev {
+		a.audit.Log(ctx, audit.Event{
+			Type:          audit.RecoveryMismatch,
+			CertificateID: fmt.Sprintf("%s", localLastCert.ID()),
+			Height:        localLastCert.Height,
+			Detail:        "previous local exit roots also differ; cannot locate the common ancestor without a by-height agglayer lookup",
+		})
+		return nil, fmt.Errorf("recovery: mismatch between local and agglayer certificates extends beyond height %d",
+			localLastCert.Height)
+	}
+
+	if err := a.BackupCertificate(ctx, *localLastCert); err != nil {
+		return nil, fmt.Errorf("recovery: error backing up local certificate %s before rolling back: %w", localLastCert.ID(), err)
+	}
+
+	rewound, err := a.updateLocalStorageWithAggLayerCert(ctx, aggLayerLastCert)
+	if err != nil {
+		return nil, fmt.Errorf("recovery: error rolling back to common ancestor at height %d: %w",
+			localLastCert.Height, err)
+	}
+
+	return rewound, nil
+}
@@ -0,0 +1,162 @@
+package aggsender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const manifestFileName = "manifest.json"
+
+// ManifestEntry records where one certificate saved under
+// cfg.SaveCertificatesToFilesPath came from, so ReplayCertificates can walk
+// a directory of certificate_*.json files in the order they were originally
+// sent without re-deriving it from filenames alone. CertificateHash is the
+// keccak256 of the saved certificate's JSON encoding, not an agglayer-issued
+// hash, since it must be computable before the certificate is ever sent.
+type ManifestEntry struct {
+	File            string      `json:"file"`
+	Height          uint64      `json:"height"`
+	CertificateHash common.Hash `json:"certificateHash"`
+	FromBlock       uint64      `json:"fromBlock"`
+	ToBlock         uint64      `json:"toBlock"`
+}
+
+// appendManifestEntry appends entry to <dir>/manifest.json, creating it if
+// needed.
+func appendManifestEntry(dir string, entry ManifestEntry) error {
+	path := filepath.Join(dir, manifestFileName)
+
+	entries, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+
+	raw, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling manifest %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil { //nolint:gosec,mnd // we are writing to a tmp file
+		return fmt.Errorf("error writing manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// readManifest loads <dir>/manifest.json. A missing file is not an error: it
+// returns an empty manifest, so replayFiles can fall back to sorting
+// filenames for a directory populated before the manifest existed.
+func readManifest(dir string) ([]ManifestEntry, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+	var entries []ManifestEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// replayFiles returns the certificate file names under dir, in the order
+// they were originally sent.
+func replayFiles(dir string) ([]string, error) {
+	entries, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		files := make([]string, len(entries))
+		for i, entry := range entries {
+			files[i] = entry.File
+		}
+		return files, nil
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing replay dir %s: %w", dir, err)
+	}
+	var files []string
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || dirEntry.Name() == manifestFileName || !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		files = append(files, dirEntry.Name())
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReplayCertificates re-signs and resubmits every certificate previously
+// saved under dir, in order, rewriting Height, PrevLocalExitRoot and
+// NetworkID to fit the current agglayer state before sending. This lets an
+// operator reproduce a bug against a fresh agglayer instance from a
+// recorded run instead of regenerating the same sequence of certificates.
+func (a *AggSender) ReplayCertificates(ctx context.Context, dir string) error {
+	files, err := replayFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no certificates to replay under %s", dir)
+	}
+
+	for _, file := range files {
+		if err := a.replayOne(ctx, filepath.Join(dir, file)); err != nil {
+			return fmt.Errorf("error replaying %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// replayOne re-signs and resubmits a single previously-saved certificate.
+func (a *AggSender) replayOne(ctx context.Context, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var saved agglayer.SignedCertificate
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		return fmt.Errorf("error unmarshalling %s: %w", path, err)
+	}
+	certificate := saved.Certificate
+	if certificate == nil {
+		return fmt.Errorf("%s has no certificate payload", path)
+	}
+
+	lastSentCertificateInfo, err := a.storage.GetLastSentCertificate()
+	if err != nil {
+		return err
+	}
+	height, previousLER, err := a.getNextHeightAndPreviousLER(lastSentCertificateInfo)
+	if err != nil {
+		return fmt.Errorf("error getting next height and previous LER to replay onto: %w", err)
+	}
+	certificate.Height = height
+	certificate.PrevLocalExitRoot = previousLER
+	certificate.NetworkID = a.l2Syncer.OriginNetwork()
+
+	signedCertificate, err := a.signCertificate(ctx, certificate)
+	if err != nil {
+		return fmt.Errorf("error re-signing replayed certificate: %w", err)
+	}
+
+	certificateHash, err := a.transport.SendCertificate(signedCertificate)
+	if err != nil {
+		return fmt.Errorf("error resubmitting replayed certificate: %w", err)
+	}
+	a.log.Infof("replayed certificate from %s, height: %d, hash: %s", path, certificate.Height, certificateHash.String())
+	return nil
+}
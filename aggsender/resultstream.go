@@ -0,0 +1,50 @@
+package aggsender
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/0xPolygon/cdk/agglayer"
+)
+
+// ResultEvent is one line of the NDJSON stream SetResultStream enables: one
+// per certificate send attempt, so a CI job can assert "N invalid certs were
+// rejected with reason X" without scraping logs.
+type ResultEvent struct {
+	Time            time.Time  `json:"time"`
+	NetworkID       uint32     `json:"networkId"`
+	Height          uint64     `json:"height"`
+	ScenarioID      ScenarioID `json:"scenarioId,omitempty"`
+	Accepted        bool       `json:"accepted"`
+	RejectionReason string     `json:"rejectionReason,omitempty"`
+	Elapsed         float64    `json:"elapsedMs"`
+}
+
+// emitResult writes one ResultEvent to a.resultStream, if set. Marshalling
+// or write errors are logged, not returned: the result stream is a
+// best-effort side channel and must never fail the send it's reporting on.
+func (a *AggSender) emitResult(
+	signedCertificate *agglayer.SignedCertificate, scenarioID ScenarioID, accepted bool, reason string, elapsed time.Duration,
+) {
+	if a.resultStream == nil {
+		return
+	}
+
+	event := ResultEvent{
+		Time:            time.Now().UTC(),
+		NetworkID:       a.l2Syncer.OriginNetwork(),
+		Height:          signedCertificate.Height,
+		ScenarioID:      scenarioID,
+		Accepted:        accepted,
+		RejectionReason: reason,
+		Elapsed:         float64(elapsed.Microseconds()) / 1000, //nolint:mnd
+	}
+	raw, err := json.Marshal(event)
+	if err != nil {
+		a.log.Errorf("error marshalling result stream event: %v", err)
+		return
+	}
+	if _, err := a.resultStream.Write(append(raw, '\n')); err != nil {
+		a.log.Errorf("error writing result stream event: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package aggsender
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultReconciliationRetryInterval = 10 * time.Second
+	retryBackoffBase                   = 5 * time.Second
+	retryBackoffMax                    = 5 * time.Minute
+)
+
+// recoverableError wraps a transient failure (agglayer 5xx, network error,
+// DB contention) that is worth retrying, as opposed to a fatal one (invalid
+// signature, height inconsistency) that retrying will never fix.
+type recoverableError struct {
+	err error
+}
+
+func newRecoverableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &recoverableError{err: err}
+}
+
+func (e *recoverableError) Error() string { return e.err.Error() }
+func (e *recoverableError) Unwrap() error { return e.err }
+
+// isRecoverable reports whether err was classified as recoverable by
+// classifyStatusErr.
+func isRecoverable(err error) bool {
+	var re *recoverableError
+	return errors.As(err, &re)
+}
+
+// classifyStatusErr wraps err as recoverable when it looks transient
+// (network/DNS errors, timeouts, connection resets, an overloaded agglayer
+// or a contended local DB), leaving it untouched - fatal - otherwise (a
+// signature or height inconsistency, which will never succeed by retrying).
+func classifyStatusErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return newRecoverableError(err)
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "signature"),
+		strings.Contains(msg, "height"),
+		strings.Contains(msg, "inconsistency"),
+		strings.Contains(msg, "mismatch"):
+		return err
+	case strings.Contains(msg, "timeout"),
+		strings.Contains(msg, "connection"),
+		strings.Contains(msg, "eof"),
+		strings.Contains(msg, "unavailable"),
+		strings.Contains(msg, "deadline exceeded"),
+		strings.Contains(msg, "too many requests"),
+		strings.Contains(msg, "internal server error"),
+		strings.Contains(msg, "database is locked"):
+		return newRecoverableError(err)
+	default:
+		return err
+	}
+}
+
+// retryState tracks one certificate's reconciliation backoff.
+type retryState struct {
+	attempts    int
+	nextRetryAt time.Time
+}
+
+// retryTracker records per-certificate backoff state for recoverable errors
+// seen while checking agglayer status, keyed by CertificateID, so the
+// reconciliation loop retries with exponential backoff instead of
+// hammering a struggling agglayer every tick.
+type retryTracker struct {
+	mu      sync.Mutex
+	entries map[common.Hash]*retryState
+}
+
+func newRetryTracker() *retryTracker {
+	return &retryTracker{entries: make(map[common.Hash]*retryState)}
+}
+
+// recordFailure registers a recoverable failure for id, doubling its backoff
+// up to retryBackoffMax.
+func (t *retryTracker) recordFailure(id common.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.entries[id]
+	if !ok {
+		state = &retryState{}
+		t.entries[id] = state
+	}
+	state.attempts++
+
+	backoff := retryBackoffBase * time.Duration(uint64(1)<<uint(state.attempts-1))
+	if backoff <= 0 || backoff > retryBackoffMax {
+		backoff = retryBackoffMax
+	}
+	state.nextRetryAt = time.Now().Add(backoff)
+}
+
+// clear drops id's backoff state, once it succeeds or its certificate closes.
+func (t *retryTracker) clear(id common.Hash) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, id)
+}
+
+// readyToRetry reports whether id has no recorded failure, or its backoff
+// has already elapsed.
+func (t *retryTracker) readyToRetry(id common.Hash) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.entries[id]
+	if !ok {
+		return true
+	}
+	return !time.Now().Before(state.nextRetryAt)
+}
+
+// due returns the set of ids whose backoff has elapsed as of now.
+func (t *retryTracker) due(now time.Time) map[common.Hash]bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	due := make(map[common.Hash]bool, len(t.entries))
+	for id, state := range t.entries {
+		if !now.Before(state.nextRetryAt) {
+			due[id] = true
+		}
+	}
+	return due
+}
@@ -0,0 +1,255 @@
+// Package rpc implements a minimal HTTP+JSON-RPC 2.0 server that exposes a
+// running AggSender's injection and control surface (InjectBridge,
+// InjectClaim, ForceSendCertificate, SetScenario, CertificateAtHeight). It
+// mirrors the bridge-RPC this spammer already consumes as a client (see
+// bridgerpc), but serves requests instead of making them, so integration
+// tests can drive deterministic scenarios against a running AggSender
+// instead of waiting for the real L2 syncer to produce bridge events.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/0xPolygon/cdk/aggsender/types"
+	"github.com/0xPolygon/cdk/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ARR552/agglayer_certificate_spammer/aggsender"
+)
+
+// Server exposes agg_* JSON-RPC methods over HTTP, backed by a single
+// AggSender instance.
+type Server struct {
+	sender *aggsender.AggSender
+}
+
+// NewServer returns a Server backed by sender.
+func NewServer(sender *aggsender.AggSender) *Server {
+	return &Server{sender: sender}
+}
+
+// ListenAndServe starts serving agg_* JSON-RPC requests on addr. It blocks
+// until ctx is done or the server fails to start.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handle)
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Close()
+	}()
+
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("aggsender rpc server stopped: %w", err)
+	}
+	return nil
+}
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: -32700, Message: "parse error: " + err.Error()}})
+		return
+	}
+
+	result, err := s.dispatch(r.Context(), req.Method, req.Params)
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	writeResponse(w, resp)
+}
+
+func writeResponse(w http.ResponseWriter, resp rpcResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "agg_injectBridge":
+		return s.injectBridge(ctx, params)
+	case "agg_injectClaim":
+		return s.injectClaim(params)
+	case "agg_forceSendCertificate":
+		return s.forceSendCertificate(ctx, params)
+	case "agg_setScenario":
+		return s.setScenario(params)
+	case "agg_getCertificate":
+		return s.getCertificate(params)
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
+
+func param(params []json.RawMessage, i int, v interface{}) error {
+	if i >= len(params) {
+		return fmt.Errorf("missing parameter %d", i)
+	}
+	if err := json.Unmarshal(params[i], v); err != nil {
+		return fmt.Errorf("error decoding parameter %d: %w", i, err)
+	}
+	return nil
+}
+
+type injectBridgeParams struct {
+	BlockNum           uint64         `json:"block_num"`
+	BlockPos           uint64         `json:"block_pos"`
+	LeafType           uint8          `json:"leaf_type"`
+	OriginNetwork      uint32         `json:"orig_net"`
+	OriginAddress      common.Address `json:"orig_addr"`
+	DestinationNetwork uint32         `json:"dest_net"`
+	DestinationAddress common.Address `json:"dest_addr"`
+	Amount             string         `json:"amount"` // decimal string, 0 if empty
+	Metadata           hexutil.Bytes  `json:"metadata"`
+	DepositCount       uint32         `json:"deposit_cnt"`
+}
+
+func (s *Server) injectBridge(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var p injectBridgeParams
+	if err := param(params, 0, &p); err != nil {
+		return nil, err
+	}
+
+	amount := big.NewInt(0)
+	if p.Amount != "" {
+		var ok bool
+		amount, ok = new(big.Int).SetString(p.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q", p.Amount)
+		}
+	}
+
+	root, err := s.sender.InjectBridge(ctx, bridgesync.Bridge{
+		BlockNum:           p.BlockNum,
+		BlockPos:           p.BlockPos,
+		LeafType:           p.LeafType,
+		OriginNetwork:      p.OriginNetwork,
+		OriginAddress:      p.OriginAddress,
+		DestinationNetwork: p.DestinationNetwork,
+		DestinationAddress: p.DestinationAddress,
+		Amount:             amount,
+		Metadata:           p.Metadata,
+		DepositCount:       p.DepositCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"deposit_cnt": p.DepositCount,
+		"root":        root.Hash.String(),
+	}, nil
+}
+
+type injectClaimParams struct {
+	BlockNum           uint64         `json:"block_num"`
+	BlockPos           uint64         `json:"block_pos"`
+	GlobalIndex        *big.Int       `json:"global_index"`
+	OriginNetwork      uint32         `json:"orig_net"`
+	OriginAddress      common.Address `json:"orig_addr"`
+	DestinationNetwork uint32         `json:"dest_net"`
+	DestinationAddress common.Address `json:"dest_addr"`
+	Amount             string         `json:"amount"` // decimal string, 0 if empty
+	Metadata           hexutil.Bytes  `json:"metadata"`
+	IsMessage          bool           `json:"is_message"`
+	GlobalExitRoot     common.Hash    `json:"global_exit_root"`
+	MainnetExitRoot    common.Hash    `json:"mainnet_exit_root"`
+	RollupExitRoot     common.Hash    `json:"rollup_exit_root"`
+}
+
+func (s *Server) injectClaim(params []json.RawMessage) (interface{}, error) {
+	var p injectClaimParams
+	if err := param(params, 0, &p); err != nil {
+		return nil, err
+	}
+	if p.GlobalIndex == nil {
+		return nil, fmt.Errorf("missing global_index")
+	}
+
+	amount := big.NewInt(0)
+	if p.Amount != "" {
+		var ok bool
+		amount, ok = new(big.Int).SetString(p.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid amount %q", p.Amount)
+		}
+	}
+
+	s.sender.InjectClaim(bridgesync.Claim{
+		BlockNum:           p.BlockNum,
+		BlockPos:           p.BlockPos,
+		GlobalIndex:        p.GlobalIndex,
+		OriginNetwork:      p.OriginNetwork,
+		OriginAddress:      p.OriginAddress,
+		DestinationNetwork: p.DestinationNetwork,
+		DestinationAddress: p.DestinationAddress,
+		Amount:             amount,
+		Metadata:           p.Metadata,
+		IsMessage:          p.IsMessage,
+		GlobalExitRoot:     p.GlobalExitRoot,
+		MainnetExitRoot:    p.MainnetExitRoot,
+		RollupExitRoot:     p.RollupExitRoot,
+	})
+
+	return map[string]interface{}{"queued": true}, nil
+}
+
+func (s *Server) forceSendCertificate(ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	storeCertificate := true
+	if len(params) > 0 {
+		if err := param(params, 0, &storeCertificate); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := s.sender.ForceSendCertificate(ctx, storeCertificate)
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *Server) setScenario(params []json.RawMessage) (interface{}, error) {
+	var scenario string
+	if err := param(params, 0, &scenario); err != nil {
+		return nil, err
+	}
+	s.sender.SetScenario(aggsender.ScenarioID(scenario))
+	return map[string]interface{}{"scenario": scenario}, nil
+}
+
+func (s *Server) getCertificate(params []json.RawMessage) (*types.CertificateInfo, error) {
+	var height uint64
+	if err := param(params, 0, &height); err != nil {
+		return nil, err
+	}
+	return s.sender.CertificateAtHeight(height)
+}
@@ -0,0 +1,107 @@
+package aggsender
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ScenarioID selects a deliberately malformed certificate shape to exercise
+// one specific agglayer rejection path instead of sending a well-formed
+// certificate. ScenarioNone sends a normal certificate.
+type ScenarioID string
+
+const (
+	ScenarioNone                 ScenarioID = ""
+	ScenarioWrongHeightReplay    ScenarioID = "wrong-height-replay"
+	ScenarioWrongHeightSkip      ScenarioID = "wrong-height-skip"
+	ScenarioMismatchedPrevLER    ScenarioID = "mismatched-prev-ler"
+	ScenarioCorruptProofLeafMER  ScenarioID = "corrupt-proof-leaf-mer"
+	ScenarioCorruptProofGERToL1  ScenarioID = "corrupt-proof-ger-to-l1"
+	ScenarioDuplicateGlobalIndex ScenarioID = "duplicate-global-index"
+	ScenarioOversizedMetadata    ScenarioID = "oversized-metadata"
+	ScenarioForeignSigner        ScenarioID = "foreign-signer"
+)
+
+// oversizedMetadataSize is large enough to trip any reasonable agglayer
+// certificate size limit on its own.
+const oversizedMetadataSize = 1 << 20 // 1 MiB
+
+// applyScenario mutates certificate in place to match scenarioID, so the
+// resulting certificate exercises one specific agglayer rejection path.
+// ScenarioForeignSigner is handled at signing time instead, see
+// sendCertificate, so it is a no-op here.
+func applyScenario(scenarioID ScenarioID, certificate *agglayer.Certificate) error {
+	switch scenarioID {
+	case ScenarioNone, ScenarioForeignSigner:
+		return nil
+
+	case ScenarioWrongHeightReplay:
+		if certificate.Height > 0 {
+			certificate.Height--
+		}
+		return nil
+
+	case ScenarioWrongHeightSkip:
+		const heightSkip = 2
+		certificate.Height += heightSkip
+		return nil
+
+	case ScenarioMismatchedPrevLER:
+		certificate.PrevLocalExitRoot = randomScenarioHash()
+		return nil
+
+	case ScenarioCorruptProofLeafMER:
+		return corruptMainnetClaim(certificate, func(claim *agglayer.ClaimFromMainnnet) {
+			claim.ProofLeafMER.Proof[0] = randomScenarioHash()
+		})
+
+	case ScenarioCorruptProofGERToL1:
+		return corruptMainnetClaim(certificate, func(claim *agglayer.ClaimFromMainnnet) {
+			claim.ProofGERToL1Root.Proof[0] = randomScenarioHash()
+		})
+
+	case ScenarioDuplicateGlobalIndex:
+		const minImportedBridgeExits = 2
+		if len(certificate.ImportedBridgeExits) < minImportedBridgeExits {
+			return fmt.Errorf("scenario %s needs at least %d imported bridge exits, got %d",
+				scenarioID, minImportedBridgeExits, len(certificate.ImportedBridgeExits))
+		}
+		certificate.ImportedBridgeExits[1].GlobalIndex = certificate.ImportedBridgeExits[0].GlobalIndex
+		return nil
+
+	case ScenarioOversizedMetadata:
+		if len(certificate.BridgeExits) == 0 {
+			return fmt.Errorf("scenario %s needs at least 1 bridge exit, got 0", scenarioID)
+		}
+		certificate.BridgeExits[0].Metadata = make([]byte, oversizedMetadataSize)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown scenario %q", scenarioID)
+	}
+}
+
+// corruptMainnetClaim runs corrupt against the first mainnet claim found
+// among certificate's imported bridge exits.
+func corruptMainnetClaim(certificate *agglayer.Certificate, corrupt func(*agglayer.ClaimFromMainnnet)) error {
+	for _, ibe := range certificate.ImportedBridgeExits {
+		if claim, ok := ibe.ClaimData.(*agglayer.ClaimFromMainnnet); ok {
+			corrupt(claim)
+			return nil
+		}
+	}
+	return fmt.Errorf("no mainnet claim found in certificate to corrupt")
+}
+
+// randomScenarioHash generates a random hash to corrupt a certificate field
+// with, deliberately diverging it from the value the agglayer expects.
+func randomScenarioHash() common.Hash {
+	var h common.Hash
+	if _, err := rand.Read(h[:]); err != nil {
+		return h
+	}
+	return h
+}
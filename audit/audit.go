@@ -0,0 +1,103 @@
+// Package audit provides a structured, typed audit trail for certificate
+// lifecycle events, separate from the routine operational logging done
+// through types.Logger. The distinction mirrors the audit-vs-info split
+// Boulder uses for its AuditErr: info logs are for following along with
+// what the process is doing, while audit events are for the specific,
+// security- and correctness-relevant transitions an operator or downstream
+// monitor needs to be able to reconstruct later, regardless of how noisy
+// or quiet the regular logs are.
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifies the kind of certificate lifecycle event being
+// recorded.
+type EventType string
+
+const (
+	// CertificateSigned is emitted once a certificate has been signed,
+	// before it is submitted to the agglayer.
+	CertificateSigned EventType = "certificate_signed"
+	// CertificateStatusChanged is emitted whenever the locally stored
+	// status of a certificate is updated to match the agglayer's view.
+	CertificateStatusChanged EventType = "certificate_status_changed"
+	// CertificateReopened is emitted when the agglayer reports a
+	// certificate as open again after local storage had it as closed,
+	// which should not normally happen.
+	CertificateReopened EventType = "certificate_reopened"
+	// RecoveryMismatch is emitted when the recovery check finds local
+	// storage and the agglayer disagree about the certificate at the same
+	// height, whether or not an automatic rollback then resolves it.
+	RecoveryMismatch EventType = "recovery_mismatch"
+	// ReorgDetected is emitted when the recovery check finds the
+	// agglayer's last known certificate at a lower height than the one in
+	// local storage, consistent with an L1 reorg.
+	ReorgDetected EventType = "reorg_detected"
+)
+
+// Event is one structured audit record. Fields that don't apply to a given
+// EventType are left at their zero value and omitted from serialized sinks.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+
+	CertificateID         string `json:"certificate_id,omitempty"`
+	Height                uint64 `json:"height,omitempty"`
+	NewLocalExitRoot      string `json:"new_local_exit_root,omitempty"`
+	PreviousLocalExitRoot string `json:"previous_local_exit_root,omitempty"`
+	SignerAddress         string `json:"signer_address,omitempty"`
+	FromStatus            string `json:"from_status,omitempty"`
+	ToStatus              string `json:"to_status,omitempty"`
+	ElapsedTime           string `json:"elapsed_time,omitempty"`
+	Detail                string `json:"detail,omitempty"`
+}
+
+// Sink receives every Event logged through a Logger. Implementations must
+// be safe for concurrent use, since certificate submission and the
+// reconciliation loop may log events from different goroutines.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// AuditLogger is the interface AggSender depends on, so the sinks it fans
+// out to can be swapped or extended (SetAuditLogger) without touching call
+// sites.
+type AuditLogger interface {
+	Log(ctx context.Context, event Event)
+}
+
+// errorLogger is the minimal logging capability Logger needs to report a
+// sink failure, satisfied directly by types.Logger.
+type errorLogger interface {
+	Errorf(format string, args ...interface{})
+}
+
+// Logger fans an Event out to every configured Sink. A Sink error doesn't
+// stop delivery to the remaining sinks; it is reported through errLog
+// instead, since losing an audit sink shouldn't take down certificate
+// submission.
+type Logger struct {
+	sinks  []Sink
+	errLog errorLogger
+}
+
+// NewLogger builds a Logger that writes every Event to each of sinks, in
+// order. errLog receives a line for any Sink that fails to write.
+func NewLogger(errLog errorLogger, sinks ...Sink) *Logger {
+	return &Logger{sinks: sinks, errLog: errLog}
+}
+
+// Log stamps event.Time if unset and writes it to every configured sink.
+func (l *Logger) Log(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	for _, sink := range l.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			l.errLog.Errorf("audit: error writing event %s to sink: %v", event.Type, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Publisher is the minimal pub/sub capability PublisherSink needs to hand
+// an event off to a message broker. Its signature matches
+// (*github.com/nats-io/nats.go.Conn).Publish exactly, so a NATS connection
+// can be passed in directly with no adapter code; a Kafka producer (or
+// anything else) only needs a small wrapper exposing this method. This
+// package intentionally doesn't import a specific messaging client itself,
+// since none is vendored in this tree - callers wire in whichever broker
+// client their deployment actually uses.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// PublisherSink serializes every Event to JSON and publishes it on subject
+// through publisher, so downstream monitoring can react to reopen and
+// mismatch events in real time instead of polling logs.
+type PublisherSink struct {
+	publisher Publisher
+	subject   string
+}
+
+// NewPublisherSink builds a PublisherSink publishing to subject through
+// publisher.
+func NewPublisherSink(publisher Publisher, subject string) *PublisherSink {
+	return &PublisherSink{publisher: publisher, subject: subject}
+}
+
+// Write publishes event as a single JSON message.
+func (s *PublisherSink) Write(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshalling audit event for publishing: %w", err)
+	}
+	if err := s.publisher.Publish(s.subject, body); err != nil {
+		return fmt.Errorf("error publishing audit event to subject %s: %w", s.subject, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// StdoutSink writes one JSON line per Event to w (os.Stdout by default), so
+// an operator tailing the process output sees the audit trail inline with
+// everything else.
+type StdoutSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdoutSink builds a StdoutSink writing to os.Stdout.
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{w: os.Stdout}
+}
+
+// Write serializes event as a single JSON line.
+func (s *StdoutSink) Write(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.NewEncoder(s.w).Encode(event)
+}
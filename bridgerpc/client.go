@@ -0,0 +1,152 @@
+// Package bridgerpc is a thin client for the CDK bridge JSON-RPC, used to
+// pull real L2 bridge events and their merkle proofs instead of fabricating
+// them from random bytes. This lets the spammer build "semi-valid"
+// certificates: real bridge/claim data paired with a deliberately broken
+// field elsewhere, which is useful to exercise agglayer invariants that
+// can't be triggered with fully-random inputs.
+package bridgerpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Client is a minimal JSON-RPC 2.0 client for the bridge service.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client pointed at the given bridge-RPC URL.
+func NewClient(url string) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("bridge-rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+	ID      int             `json:"id"`
+}
+
+func (c *Client) call(method string, params []interface{}, result interface{}) error {
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      1,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling bridge-rpc request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(c.url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("error calling bridge-rpc method %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("error decoding bridge-rpc response for method %s: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("error unmarshalling bridge-rpc result for method %s: %w", method, err)
+	}
+	return nil
+}
+
+// BridgeEvent mirrors a single leaf emitted by the bridge contract, as
+// reported by the bridge-RPC's `bridge_getBridges` method.
+type BridgeEvent struct {
+	LeafType           uint8          `json:"leaf_type"`
+	OriginNetwork      uint32         `json:"orig_net"`
+	OriginAddress      common.Address `json:"orig_addr"`
+	DestinationNetwork uint32         `json:"dest_net"`
+	DestinationAddress common.Address `json:"dest_addr"`
+	Amount             string         `json:"amount"` // decimal string, decoded by the caller
+	Metadata           hexBytes       `json:"metadata"`
+	DepositCount       uint32         `json:"deposit_cnt"`
+}
+
+// hexBytes decodes a 0x-prefixed hex string into []byte.
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// LatestBridges returns the `count` most recent bridge events for networkID.
+func (c *Client) LatestBridges(networkID uint32, count int) ([]BridgeEvent, error) {
+	var events []BridgeEvent
+	if err := c.call("bridge_getBridges", []interface{}{networkID, count}, &events); err != nil {
+		return nil, fmt.Errorf("error fetching latest bridges for network %d: %w", networkID, err)
+	}
+	return events, nil
+}
+
+// ClaimProof is the merkle proof bundle returned by `bridge_getClaimProof`
+// for a given deposit, sufficient to populate ClaimFromMainnnet/ClaimFromRollup.
+type ClaimProof struct {
+	ProofLocalExitRoot  [32]common.Hash `json:"proof_local_exit_root"`
+	ProofRollupExitRoot [32]common.Hash `json:"proof_rollup_exit_root"`
+	MainnetExitRoot     common.Hash     `json:"mainnet_exit_root"`
+	RollupExitRoot      common.Hash     `json:"rollup_exit_root"`
+	L1InfoTreeIndex     uint32          `json:"l1_info_tree_index"`
+	GlobalExitRoot      common.Hash     `json:"global_exit_root"`
+	BlockHash           common.Hash     `json:"block_hash"`
+	Timestamp           uint64          `json:"timestamp"`
+}
+
+// ClaimProofForDeposit returns the claim proof for the given deposit count on
+// networkID, as known by the bridge-RPC's L1 info tree at leafIndex.
+func (c *Client) ClaimProofForDeposit(networkID, depositCount, leafIndex uint32) (*ClaimProof, error) {
+	var proof ClaimProof
+	if err := c.call("bridge_getClaimProof", []interface{}{networkID, depositCount, leafIndex}, &proof); err != nil {
+		return nil, fmt.Errorf("error fetching claim proof for network %d deposit %d: %w", networkID, depositCount, err)
+	}
+	return &proof, nil
+}
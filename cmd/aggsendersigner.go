@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// buildAggSenderSigner selects a signer.CertSigner to override the
+// LocalSigner the AggSender was constructed with (the configured aggsender
+// key, a generated key, or one derived via --signer-source, depending on the
+// command) from --signer-url, --signer-grpc-addr or --kms-key-id. It returns
+// a nil signer when none of them are set, leaving that initial key in place.
+func buildAggSenderSigner(ctx *cli.Context) (signer.CertSigner, error) {
+	signerURL := ctx.String(signerURLFlagName)
+	kmsKeyID := ctx.String(kmsKeyIDFlagName)
+	grpcAddr := ctx.String(signerGRPCAddrFlagName)
+
+	switch {
+	case signerURL != "":
+		signerAddress := ctx.String(signerAddressFlagName)
+		if signerAddress == "" {
+			return nil, fmt.Errorf("--%s is required when using --%s", signerAddressFlagName, signerURLFlagName)
+		}
+		log.Infof("Signing certificates with remote signer at %s, account %s", signerURL, signerAddress)
+		return signer.NewRemoteSigner(signerURL, ethcommon.HexToAddress(signerAddress)), nil
+
+	case grpcAddr != "":
+		grpcSigner, err := buildGRPCSigner(ctx, grpcAddr)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing certificates with grpc signer at %s, address %s", grpcAddr, grpcSigner.Address().Hex())
+		return grpcSigner, nil
+
+	case kmsKeyID != "":
+		awsCfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error loading AWS config: %w", err)
+		}
+		kmsSigner, err := signer.NewKMSSigner(context.Background(), kms.NewFromConfig(awsCfg), kmsKeyID)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing certificates with KMS key %s, address %s", kmsKeyID, kmsSigner.Address().Hex())
+		return kmsSigner, nil
+
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/0xPolygon/cdk/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ARR552/agglayer_certificate_spammer/audit"
+)
+
+// buildAuditLogger selects an audit.AuditLogger to override the AggSender's
+// default (JSON lines to stdout only) from --audit-file. It returns a nil
+// logger, leaving the default in place, when --audit-file isn't set.
+func buildAuditLogger(ctx *cli.Context) (audit.AuditLogger, error) {
+	auditFile := ctx.String(auditFileFlagName)
+	if auditFile == "" {
+		return nil, nil
+	}
+
+	fileSink, err := audit.NewRotatingFileSink(auditFile, ctx.Int64(auditFileMaxBytesFlagName))
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Writing certificate audit trail to %s", auditFile)
+
+	return audit.NewLogger(log.WithFields("module", "audit"), audit.NewStdoutSink(), fileSink), nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/corpus"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
+	"github.com/urfave/cli/v2"
+)
+
+// replayCorpusEntry resends a certificate previously saved to the corpus,
+// unmodified, so a reported agglayer failure can be reproduced on demand.
+func replayCorpusEntry(ctx *cli.Context) error {
+	url := ctx.String(urlFlagName)
+	corpusDir := ctx.String(corpusDirFlagName)
+	hash := ctx.String(corpusHashFlagName)
+
+	store, err := corpus.NewStore(corpusDir)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	cert, _, err := store.Load(hash)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	replayHash, sendErr := agglayer.NewAggLayerClient(url).SendCertificate(cert)
+	if sendErr != nil {
+		log.Error(sendErr)
+		return sendErr
+	}
+	log.Infof("replayed corpus entry %s, agglayer returned hash %s", hash, replayHash.String())
+	return nil
+}
+
+// shrinkCorpusEntry minimizes a corpus certificate that produced a given
+// agglayer error, keeping only the smallest input that still reproduces the
+// same error class, and saves the result back to the corpus as a new entry.
+func shrinkCorpusEntry(ctx *cli.Context) error {
+	url := ctx.String(urlFlagName)
+	corpusDir := ctx.String(corpusDirFlagName)
+	hash := ctx.String(corpusHashFlagName)
+
+	store, err := corpus.NewStore(corpusDir)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	cert, result, err := store.Load(hash)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if result.Code == "" {
+		return fmt.Errorf("corpus entry %s has no recorded error to reproduce", hash)
+	}
+
+	certSigner, err := buildCertSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	client := agglayer.NewAggLayerClient(url)
+	send := func(candidate *agglayer.SignedCertificate) string {
+		_, sendErr := client.SendCertificate(candidate)
+		return spammer.ClassifyAgglayerError(sendErr)
+	}
+
+	minimized, err := corpus.Shrink(ctx.Context, cert, result.Code, certSigner, send)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	minimizedHash, err := store.Save(minimized, errors.New(result.Error), result.Code)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	log.Infof("shrunk corpus entry %s into %s", hash, minimizedHash)
+	return nil
+}
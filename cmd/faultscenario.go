@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/fault"
+	"github.com/urfave/cli/v2"
+)
+
+// buildFaultPicker loads --fault-scenario-file into a *fault.Picker, for the
+// caller to install with AggSender.SetFaultScenario. It returns a nil picker,
+// leaving --fuzz-scenario in sole control, when --fault-scenario-file isn't
+// set.
+func buildFaultPicker(ctx *cli.Context) (*fault.Picker, error) {
+	path := ctx.String(faultScenarioFileFlagName)
+	if path == "" {
+		return nil, nil
+	}
+
+	scn, err := fault.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	picker, err := fault.NewPicker(scn)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Loaded fault scenario %s: %d mutations, iterations=%d", path, len(scn.Mutations), scn.Iterations)
+
+	return picker, nil
+}
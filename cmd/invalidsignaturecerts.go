@@ -1,7 +1,6 @@
 package main
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"runtime"
 
@@ -9,6 +8,10 @@ import (
 	"github.com/0xPolygon/cdk/config"
 	"github.com/0xPolygon/cdk/etherman"
 	"github.com/0xPolygon/cdk/log"
+	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	aggsenderrpc "github.com/ARR552/agglayer_certificate_spammer/aggsender/rpc"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ARR552/agglayer_certificate_spammer/spammererr"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -23,6 +26,7 @@ func sendInvalidSignatureCerts(ctx *cli.Context) error {
 	emptyCert := ctx.Bool(emptyCertFlagName)
 	addFakeBridge := ctx.Bool(addFakeBridgeFlagName)
 	storeCertificate := ctx.Bool(storeCertificateFlagName)
+	singleCert := ctx.Bool(singleCertFlagName)
 
 	log.Init(cfg.Log)
 
@@ -39,19 +43,19 @@ func sendInvalidSignatureCerts(ctx *cli.Context) error {
 	l1Client, err := ethclient.Dial(urlRPCL1)
 	if err != nil {
 		log.Errorf("failed to create client for L1 using URL: %s. Err:%v", urlRPCL1, err)
-		return err
+		return spammererr.Wrap(spammererr.ErrL1Dial, err)
 	}
 	urlRPCL2 := getL2RPCUrl(cfg)
 	log.Infof("dialing L2 client at: %s", urlRPCL2)
 	l2Client, err := ethclient.Dial(urlRPCL2)
 	if err != nil {
 		log.Error(err)
-		return err
+		return spammererr.Wrap(spammererr.ErrL2Dial, err)
 	}
 	reorgDetectorL1, errChanL1, err := runReorgDetectorL1(ctx.Context, l1Client, &cfg.ReorgDetectorL1)
 	if err != nil {
 		log.Error("Error from ReorgDetectorL1: ", err)
-		return err
+		return spammererr.Wrap(spammererr.ErrReorgDetector, err)
 	}
 	go func() {
 		if err := <-errChanL1; err != nil {
@@ -62,7 +66,7 @@ func sendInvalidSignatureCerts(ctx *cli.Context) error {
 	reorgDetectorL2, errChanL2, err := runReorgDetectorL2(ctx.Context, l2Client, &cfg.ReorgDetectorL2)
 	if err != nil {
 		log.Error("Error from ReorgDetectorL2: ", err)
-		return err
+		return spammererr.Wrap(spammererr.ErrReorgDetector, err)
 	}
 	go func() {
 		if err := <-errChanL2; err != nil {
@@ -73,32 +77,38 @@ func sendInvalidSignatureCerts(ctx *cli.Context) error {
 	rollupID, err := etherman.GetRollupID(cfg.NetworkConfig.L1Config, cfg.NetworkConfig.L1Config.ZkEVMAddr, l1Client)
 	if err != nil {
 		log.Error(err)
-		return err
+		return spammererr.Wrap(spammererr.ErrRollupLookup, err)
 	}
 	l1InfoTreeSync, err := runL1InfoTreeSyncer(ctx.Context, *cfg, l1Client, reorgDetectorL1)
 	if err != nil {
 		log.Error(err)
-		return err
+		return spammererr.Wrap(spammererr.ErrSyncerStart, err)
 	}
 	l2BridgeSync, err := runBridgeSyncL2(ctx.Context, cfg.BridgeL2Sync, reorgDetectorL2, l2Client, rollupID)
 	if err != nil {
 		log.Error(err)
-		return err
+		return spammererr.Wrap(spammererr.ErrSyncerStart, err)
 	}
-	randomPrivateKey, err := crypto.GenerateKey()
+
+	if concurrency := ctx.Int(concurrencyFlagName); concurrency > 1 {
+		return runLoadMode(ctx, cfg, l1Client, l1InfoTreeSync, l2BridgeSync, emptyCert, addFakeBridge, storeCertificate, concurrency)
+	}
+
+	initialSigner, err := resolveSignerSource(ctx)
 	if err != nil {
 		log.Error(err)
-		return err
+		return spammererr.Wrap(spammererr.ErrSignerInit, err)
 	}
-	log.Info("Random Private Key generated:", hexutil.Encode(crypto.FromECDSA(randomPrivateKey)))
-
-	publicKey := randomPrivateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Error("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-		return fmt.Errorf("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
+	if initialSigner == nil {
+		randomPrivateKey, err := crypto.GenerateKey()
+		if err != nil {
+			log.Error(err)
+			return spammererr.Wrap(spammererr.ErrSignerInit, err)
+		}
+		log.Info("Random Private Key generated:", hexutil.Encode(crypto.FromECDSA(randomPrivateKey)))
+		initialSigner = signer.NewLocalSigner(randomPrivateKey)
 	}
-	log.Info("Generated wallet Address:", crypto.PubkeyToAddress(*publicKeyECDSA).Hex())
+	log.Info("Generated wallet Address:", initialSigner.Address().Hex())
 	aggsender, err := createAggSender(
 		ctx.Context,
 		cfg.AggSender,
@@ -106,13 +116,63 @@ func sendInvalidSignatureCerts(ctx *cli.Context) error {
 		l1InfoTreeSync,
 		l2BridgeSync,
 		cfg.BridgeL2Sync.DBPath,
-		randomPrivateKey,
+		initialSigner,
 	)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
-	go aggsender.Start(ctx.Context, emptyCert, addFakeBridge, storeCertificate)
+	certTransport, err := buildCertTransport(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if certTransport != nil {
+		aggsender.SetTransport(certTransport)
+	}
+
+	certSigner, err := buildAggSenderSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if certSigner != nil {
+		aggsender.SetSigner(certSigner)
+	}
+
+	if ctx.Bool(allowReorgRecoveryFlagName) {
+		aggsender.SetAllowReorgRecovery(true)
+	}
+
+	if auditLogger, err := buildAuditLogger(ctx); err != nil {
+		log.Error(err)
+		return err
+	} else if auditLogger != nil {
+		aggsender.SetAuditLogger(auditLogger)
+	}
+
+	if faultPicker, err := buildFaultPicker(ctx); err != nil {
+		log.Error(err)
+		return err
+	} else if faultPicker != nil {
+		aggsender.SetFaultScenario(faultPicker)
+	}
+
+	if resultStream := buildResultStream(ctx); resultStream != nil {
+		aggsender.SetResultStream(resultStream)
+	}
+
+	if rpcAddr := ctx.String(rpcAddrFlagName); rpcAddr != "" {
+		rpcServer := aggsenderrpc.NewServer(aggsender)
+		log.Infof("starting agg_* rpc server on %s", rpcAddr)
+		go func() {
+			if err := rpcServer.ListenAndServe(ctx.Context, rpcAddr); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+	applyRateLimit(ctx, aggsender)
+	go aggsender.Start(ctx.Context, emptyCert, addFakeBridge, storeCertificate, singleCert, spammerAggsender.ScenarioID(ctx.String(fuzzScenarioFlagName)))
 	waitSignal(nil)
 
 	return nil
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk/aggsender"
+	"github.com/0xPolygon/cdk/bridgesync"
+	"github.com/0xPolygon/cdk/config"
+	"github.com/0xPolygon/cdk/l1infotreesync"
+	"github.com/0xPolygon/cdk/log"
+	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+)
+
+// buildLoadSenders creates concurrency independent AggSenders sharing the
+// same l1InfoTreeSync and l2BridgeSync, each with its own freshly generated
+// signing key and its own StoragePath, so N certificate streams can be in
+// flight against the agglayer at once without fighting over the same
+// sqlite-backed AggSenderStorage/CertificateHistory.
+func buildLoadSenders(
+	ctx context.Context,
+	cfg aggsender.Config,
+	l1Client *ethclient.Client,
+	l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
+	l2BridgeSync *bridgesync.BridgeSync,
+	bridgeDB string,
+	concurrency int,
+) ([]*spammerAggsender.AggSender, error) {
+	senders := make([]*spammerAggsender.AggSender, 0, concurrency)
+	for i := 0; i < concurrency; i++ {
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("error generating load worker %d key: %w", i, err)
+		}
+		log.Infof("load worker %d wallet address: %s", i, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+
+		workerCfg := cfg
+		workerCfg.StoragePath = fmt.Sprintf("%s.worker%d", cfg.StoragePath, i)
+
+		sender, err := createAggSender(
+			ctx,
+			workerCfg,
+			l1Client,
+			l1InfoTreeSync,
+			l2BridgeSync,
+			bridgeDB,
+			signer.NewLocalSigner(privateKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating load worker %d aggsender: %w", i, err)
+		}
+		senders = append(senders, sender)
+	}
+
+	return senders, nil
+}
+
+// applyLoadRateLimit splits --rate evenly across senders, so --rate names
+// the aggregate certs/sec the whole load run should produce regardless of
+// --concurrency. Without --rate it falls back to applying the usual
+// --certs-per-second/--burst/--jitter to every sender unchanged. When
+// --metrics-addr is set, every sender shares a single RateMetrics bound to
+// one registry, so the scraped totals reflect the whole run rather than one
+// worker.
+func applyLoadRateLimit(ctx *cli.Context, senders []*spammerAggsender.AggSender) {
+	rate := ctx.Float64(rateFlagName)
+	burst := ctx.Int(burstFlagName)
+	jitter := ctx.Duration(jitterFlagName)
+	if rate > 0 {
+		perWorker := rate / float64(len(senders))
+		for _, sender := range senders {
+			sender.SetRateLimit(perWorker, burst, jitter)
+		}
+	} else {
+		certsPerSecond := ctx.Float64(certsPerSecondFlagName)
+		for _, sender := range senders {
+			sender.SetRateLimit(certsPerSecond, burst, jitter)
+		}
+	}
+
+	metricsAddr := ctx.String(metricsAddrFlagName)
+	if metricsAddr == "" {
+		return
+	}
+	reg := prometheus.NewRegistry()
+	metrics := spammerAggsender.NewRateMetrics(reg)
+	for _, sender := range senders {
+		sender.SetRateMetrics(metrics)
+	}
+	go spammer.ServeMetrics(ctx.Context, metricsAddr, reg)
+}
+
+// awaitLoadDuration cancels cancel once --duration elapses, if set, so a
+// load run can bound itself without requiring an operator to send a signal.
+func awaitLoadDuration(ctx *cli.Context, cancel context.CancelFunc) {
+	duration := ctx.Duration(durationFlagName)
+	if duration <= 0 {
+		return
+	}
+	go func() {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			log.Infof("--duration of %s elapsed, stopping load run", duration)
+			cancel()
+		case <-ctx.Context.Done():
+		}
+	}()
+}
+
+// runLoadMode spins up --concurrency independent aggsender workers against
+// the same l1InfoTreeSync/l2BridgeSync, each with its own generated key and
+// certificate stream, so a single invocation can load-test the agglayer's
+// admission pipeline instead of the usual one-cert-at-a-time behavior.
+func runLoadMode(
+	ctx *cli.Context,
+	cfg *config.Config,
+	l1Client *ethclient.Client,
+	l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
+	l2BridgeSync *bridgesync.BridgeSync,
+	emptyCert, addFakeBridge, storeCertificate bool,
+	concurrency int,
+) error {
+	log.Infof("load mode: starting %d concurrent aggsender workers", concurrency)
+
+	senders, err := buildLoadSenders(
+		ctx.Context, cfg.AggSender, l1Client, l1InfoTreeSync, l2BridgeSync, cfg.BridgeL2Sync.DBPath, concurrency,
+	)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	certTransport, err := buildCertTransport(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	certSigner, err := buildAggSenderSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	auditLogger, err := buildAuditLogger(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	faultPicker, err := buildFaultPicker(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	resultStream := buildResultStream(ctx)
+
+	for _, sender := range senders {
+		if certTransport != nil {
+			sender.SetTransport(certTransport)
+		}
+		if certSigner != nil {
+			sender.SetSigner(certSigner)
+		}
+		if ctx.Bool(allowReorgRecoveryFlagName) {
+			sender.SetAllowReorgRecovery(true)
+		}
+		if auditLogger != nil {
+			sender.SetAuditLogger(auditLogger)
+		}
+		if faultPicker != nil {
+			// A single shared Picker is intentional: its iteration cap then
+			// bounds the whole load run's mutated certificates, not just one
+			// worker's share of them.
+			sender.SetFaultScenario(faultPicker)
+		}
+		if resultStream != nil {
+			sender.SetResultStream(resultStream)
+		}
+	}
+
+	applyLoadRateLimit(ctx, senders)
+
+	runCtx, cancel := context.WithCancel(ctx.Context)
+	defer cancel()
+	awaitLoadDuration(ctx, cancel)
+
+	scenarioID := spammerAggsender.ScenarioID(ctx.String(fuzzScenarioFlagName))
+	for _, sender := range senders {
+		// singleCert is always false here: it asks a single AggSender to stop
+		// after sending one certificate, which is meaningless when the point
+		// of load mode is several of them streaming certificates concurrently.
+		go sender.Start(runCtx, emptyCert, addFakeBridge, storeCertificate, false, scenarioID)
+	}
+
+	if ctx.String(rpcAddrFlagName) != "" {
+		log.Warn("rpc-addr is a no-op in load mode: agg_* rpc serves a single aggsender, not a pool of them")
+	}
+
+	waitSignal(nil)
+
+	return nil
+}
@@ -5,20 +5,64 @@ import (
 
 	"github.com/0xPolygon/cdk/config"
 	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/spammererr"
 	"github.com/urfave/cli/v2"
 )
 
 const (
-	urlFlagName               = "url"
-	validSignatureFlagName    = "valid-signature"
-	privateKeyFlagName        = "private-key"
-	emptyCertFlagName         = "empty-cert"
-	addFakeBridgeFlagName     = "add-fake-bridge"
-	storeCertificateFlagName  = "store-certificate"
-	networkIDFlagName         = "network-id"
-	singleCertFlagName        = "single-cert"
-	certHeightFlagName        = "height"
-	randomGlobalIndexFlagName = "random-global-index"
+	urlFlagName                  = "url"
+	validSignatureFlagName       = "valid-signature"
+	privateKeyFlagName           = "private-key"
+	emptyCertFlagName            = "empty-cert"
+	addFakeBridgeFlagName        = "add-fake-bridge"
+	storeCertificateFlagName     = "store-certificate"
+	networkIDFlagName            = "network-id"
+	singleCertFlagName           = "single-cert"
+	certHeightFlagName           = "height"
+	randomGlobalIndexFlagName    = "random-global-index"
+	scenarioFileFlagName         = "scenario-file"
+	bridgeRPCFlagName            = "bridge-rpc"
+	workersFlagName              = "workers"
+	rateFlagName                 = "rate"
+	durationFlagName             = "duration"
+	metricsAddrFlagName          = "metrics-addr"
+	corpusDirFlagName            = "corpus-dir"
+	corpusHashFlagName           = "corpus-hash"
+	keystoreFileFlagName         = "keystore-file"
+	keystorePasswordFlagName     = "keystore-password"
+	signerURLFlagName            = "signer-url"
+	signerAddressFlagName        = "signer-address"
+	kmsKeyIDFlagName             = "kms-key-id"
+	fuzzScenarioFlagName         = "fuzz-scenario"
+	rpcAddrFlagName              = "rpc-addr"
+	certTransportFlagName        = "cert-transport"
+	certTransportAddrFlagName    = "cert-transport-addr"
+	certTransportDirFlagName     = "cert-transport-dir"
+	replayDirFlagName            = "replay-dir"
+	certsPerSecondFlagName       = "certs-per-second"
+	burstFlagName                = "burst"
+	jitterFlagName               = "jitter"
+	allowReorgRecoveryFlagName   = "allow-reorg-recovery"
+	restoreCertificateIDFlagName = "certificate-id"
+	restoreUpdatedAtFlagName     = "updated-at"
+	auditFileFlagName            = "audit-file"
+	auditFileMaxBytesFlagName    = "audit-file-max-bytes"
+	signerSourceFlagName         = "signer-source"
+	mnemonicFlagName             = "mnemonic"
+	derivationPathFlagName       = "derivation-path"
+	seedFlagName                 = "seed"
+	signerIndexFlagName          = "signer-index"
+	signerCountFlagName          = "signer-count"
+	signerGRPCAddrFlagName       = "signer-grpc-addr"
+	signerGRPCKeyIDFlagName      = "signer-grpc-key-id"
+	signerGRPCTLSCAFlagName      = "signer-grpc-tls-ca"
+	signerGRPCTLSCertFlagName    = "signer-grpc-tls-cert"
+	signerGRPCTLSKeyFlagName     = "signer-grpc-tls-key"
+	faultScenarioFileFlagName    = "fault-scenario-file"
+	resultStreamFlagName         = "result-stream"
+	concurrencyFlagName          = "concurrency"
+	networkIDsFlagName           = "network-ids"
+	l2RPCURLsFlagName            = "l2-rpc-urls"
 )
 
 var (
@@ -93,6 +137,233 @@ var (
 		Usage:    "Force the GlobalIndex to be fully random. MainnetFlag (true) and rollupIndex (!= 0) at the same time",
 		Required: false,
 	}
+	scenarioFileFlag = cli.StringFlag{
+		Name:     scenarioFileFlagName,
+		Aliases:  []string{"scenario"},
+		Usage:    "Path to a YAML/JSON scenario file describing a deterministic batch of certificates",
+		Required: true,
+	}
+	bridgeRPCFlag = cli.StringFlag{
+		Name:     bridgeRPCFlagName,
+		Usage:    "URL of a bridge-RPC endpoint to pull real bridges/claims from instead of generating random ones. Empty falls back to the random path",
+		Required: false,
+	}
+	workersFlag = cli.IntFlag{
+		Name:     workersFlagName,
+		Usage:    "Number of concurrent worker goroutines generating, signing and sending certificates",
+		Value:    1,
+		Required: false,
+	}
+	rateFlag = cli.Float64Flag{
+		Name:     rateFlagName,
+		Usage:    "Maximum aggregate certificates/sec sent across all workers. 0 means unlimited",
+		Required: false,
+	}
+	durationFlag = cli.DurationFlag{
+		Name:     durationFlagName,
+		Usage:    "How long to keep spamming certificates. 0 means run until interrupted, except that random-certs with one worker and no --rate then sends a single certificate. invalid-signature-certs reads it only with --concurrency above 1",
+		Required: false,
+	}
+	metricsAddrFlag = cli.StringFlag{
+		Name:     metricsAddrFlagName,
+		Usage:    "Address to serve Prometheus metrics on (e.g. :9090). Empty disables metrics",
+		Required: false,
+	}
+	corpusDirFlag = cli.StringFlag{
+		Name:     corpusDirFlagName,
+		Usage:    "Directory to persist every sent certificate and its agglayer response to. Empty disables the corpus",
+		Value:    "corpus",
+		Required: false,
+	}
+	corpusHashFlag = cli.StringFlag{
+		Name:     corpusHashFlagName,
+		Usage:    "Hash of the corpus entry to operate on",
+		Required: true,
+	}
+	keystoreFileFlag = cli.StringFlag{
+		Name:     keystoreFileFlagName,
+		Usage:    "Path to a Web3 Secret Storage V3 keystore file holding the signing key",
+		Required: false,
+	}
+	keystorePasswordFlag = cli.StringFlag{
+		Name:     keystorePasswordFlagName,
+		Usage:    "Passphrase for --keystore-file. If empty, falls back to the KEYSTORE_PASSWORD env var",
+		Required: false,
+	}
+	signerURLFlag = cli.StringFlag{
+		Name:     signerURLFlagName,
+		Usage:    "URL of a Clef-compatible remote signer (http(s):// or unix:///path/to/clef.ipc) to sign with instead of a local key",
+		Required: false,
+	}
+	signerAddressFlag = cli.StringFlag{
+		Name:     signerAddressFlagName,
+		Usage:    "Account address to request signatures for from --signer-url",
+		Required: false,
+	}
+	signerGRPCAddrFlag = cli.StringFlag{
+		Name:     signerGRPCAddrFlagName,
+		Usage:    "host:port of a gRPC signer.GRPCSigningServer-compatible signing daemon to sign with instead of a local key",
+		Required: false,
+	}
+	signerGRPCKeyIDFlag = cli.StringFlag{
+		Name:     signerGRPCKeyIDFlagName,
+		Usage:    "Key ID to request signatures for from --signer-grpc-addr",
+		Required: false,
+	}
+	signerGRPCTLSCAFlag = cli.StringFlag{
+		Name:     signerGRPCTLSCAFlagName,
+		Usage:    "PEM-encoded CA certificate to verify --signer-grpc-addr against. Empty dials insecurely",
+		Required: false,
+	}
+	signerGRPCTLSCertFlag = cli.StringFlag{
+		Name:     signerGRPCTLSCertFlagName,
+		Usage:    "PEM-encoded client certificate to present to --signer-grpc-addr for mTLS. Requires --signer-grpc-tls-key",
+		Required: false,
+	}
+	signerGRPCTLSKeyFlag = cli.StringFlag{
+		Name:     signerGRPCTLSKeyFlagName,
+		Usage:    "PEM-encoded private key for --signer-grpc-tls-cert",
+		Required: false,
+	}
+	kmsKeyIDFlag = cli.StringFlag{
+		Name:     kmsKeyIDFlagName,
+		Usage:    "AWS KMS key ID/ARN of an asymmetric ECC_SECG_P256K1 key to sign with instead of a local key",
+		Required: false,
+	}
+	fuzzScenarioFlag = cli.StringFlag{
+		Name: fuzzScenarioFlagName,
+		Usage: "Send deliberately malformed certificates matching this scenario instead of well-formed ones. One of: " +
+			"wrong-height-replay, wrong-height-skip, mismatched-prev-ler, corrupt-proof-leaf-mer, corrupt-proof-ger-to-l1, " +
+			"duplicate-global-index, oversized-metadata, foreign-signer. Empty disables fuzzing",
+		Required: false,
+	}
+	rpcAddrFlag = cli.StringFlag{
+		Name:     rpcAddrFlagName,
+		Usage:    "Address to serve the agg_* JSON-RPC control API on (e.g. :8555), letting a caller inject bridges/claims and force certificates on demand. Empty disables it",
+		Required: false,
+	}
+	certTransportFlag = cli.StringFlag{
+		Name:     certTransportFlagName,
+		Usage:    "How certificates are actually sent: http (default), grpc, or file-drop",
+		Value:    "http",
+		Required: false,
+	}
+	certTransportAddrFlag = cli.StringFlag{
+		Name:     certTransportAddrFlagName,
+		Usage:    "Address to dial for --cert-transport=grpc",
+		Required: false,
+	}
+	certTransportDirFlag = cli.StringFlag{
+		Name:     certTransportDirFlagName,
+		Usage:    "Directory to atomically drop certificates into for --cert-transport=file-drop",
+		Required: false,
+	}
+	replayDirFlag = cli.StringFlag{
+		Name:     replayDirFlagName,
+		Usage:    "Directory of certificate_*.json files (and manifest.json) previously saved via save-certificates-to-files-path, to re-sign and resubmit in order",
+		Required: true,
+	}
+	certsPerSecondFlag = cli.Float64Flag{
+		Name:     certsPerSecondFlagName,
+		Usage:    "Steady-state rate limit for the aggsender send loop, in certificates/sec. 0 means unlimited",
+		Value:    1,
+		Required: false,
+	}
+	burstFlag = cli.IntFlag{
+		Name:     burstFlagName,
+		Usage:    "How many certificates the aggsender send loop can send back-to-back before --certs-per-second applies",
+		Value:    1,
+		Required: false,
+	}
+	jitterFlag = cli.DurationFlag{
+		Name:     jitterFlagName,
+		Usage:    "Random extra delay added after each send-loop token is granted, uniform in [0, jitter); the first token after a new epoch gets a second such delay. 0 disables jitter",
+		Required: false,
+	}
+	allowReorgRecoveryFlag = cli.BoolFlag{
+		Name:     allowReorgRecoveryFlagName,
+		Usage:    "Allow the recovery check to automatically rewind local state when it disagrees with agglayer's last certificate, instead of failing and requiring operator intervention",
+		Required: false,
+	}
+	restoreCertificateIDFlag = cli.StringFlag{
+		Name:     restoreCertificateIDFlagName,
+		Usage:    "CertificateID (hash) of the certificate to restore",
+		Required: true,
+	}
+	restoreUpdatedAtFlag = cli.UintFlag{
+		Name:     restoreUpdatedAtFlagName,
+		Usage:    "UpdatedAt (unix timestamp) of the certificate_history snapshot to restore",
+		Required: true,
+	}
+	auditFileFlag = cli.StringFlag{
+		Name:     auditFileFlagName,
+		Usage:    "Path to a rotating, tamper-evident audit log file to also write certificate lifecycle events to, in addition to the stdout JSON audit trail. Empty disables it",
+		Required: false,
+	}
+	auditFileMaxBytesFlag = cli.Int64Flag{
+		Name:     auditFileMaxBytesFlagName,
+		Usage:    "Rotate --audit-file once it would exceed this many bytes. 0 or less disables rotation",
+		Value:    10 * 1024 * 1024, //nolint:mnd
+		Required: false,
+	}
+	faultScenarioFileFlag = cli.StringFlag{
+		Name:     faultScenarioFileFlagName,
+		Usage:    "Path to a YAML/JSON fault.Scenario file describing a weighted mix of malformed certificate shapes to send instead of (or alongside) well-formed ones. Empty disables it and leaves --fuzz-scenario in sole control",
+		Required: false,
+	}
+	resultStreamFlag = cli.BoolFlag{
+		Name:     resultStreamFlagName,
+		Usage:    "Write one NDJSON aggsender.ResultEvent line to stdout per certificate send attempt, so a CI job can assert on exactly which certificates were accepted/rejected and why",
+		Required: false,
+	}
+	concurrencyFlag = cli.IntFlag{
+		Name:     concurrencyFlagName,
+		Usage:    "Number of concurrent aggsender workers to run, each with its own generated signing key and certificate stream, for load-testing the agglayer's admission pipeline. 1 disables load mode",
+		Value:    1,
+		Required: false,
+	}
+	signerSourceFlag = cli.StringFlag{
+		Name:     signerSourceFlagName,
+		Usage:    "Where to derive the initial signing key from: mnemonic or seed. Empty uses the command's own default (the configured aggsender key for valid-certs, a freshly generated key for invalid-signature-certs)",
+		Required: false,
+	}
+	mnemonicFlag = cli.StringFlag{
+		Name:     mnemonicFlagName,
+		Usage:    "Mnemonic phrase to deterministically derive the signing key from, for --signer-source=mnemonic",
+		Required: false,
+	}
+	derivationPathFlag = cli.StringFlag{
+		Name:     derivationPathFlagName,
+		Usage:    "BIP-32-style derivation path (e.g. m/44'/60'/0'/0/0) applied to --mnemonic, for --signer-source=mnemonic",
+		Value:    "m/44'/60'/0'/0/0",
+		Required: false,
+	}
+	seedFlag = cli.StringFlag{
+		Name:     seedFlagName,
+		Usage:    "Hex-encoded seed to deterministically derive --signer-count signers from, for --signer-source=seed",
+		Required: false,
+	}
+	signerIndexFlag = cli.IntFlag{
+		Name:     signerIndexFlagName,
+		Usage:    "Which of the --signer-count seed-derived signers this process should use, for --signer-source=seed",
+		Required: false,
+	}
+	signerCountFlag = cli.IntFlag{
+		Name:     signerCountFlagName,
+		Usage:    "How many distinct signers to derive from --seed, for --signer-source=seed",
+		Value:    1,
+		Required: false,
+	}
+	networkIDsFlag = cli.StringSliceFlag{
+		Name:     networkIDsFlagName,
+		Usage:    "NetworkIDs to drive in parallel against the same agglayer, one independent AggSender per id, paired positionally with --l2-rpc-urls",
+		Required: true,
+	}
+	l2RPCURLsFlag = cli.StringSliceFlag{
+		Name:     l2RPCURLsFlagName,
+		Usage:    "L2 RPC URL for each rollup in --network-ids, in the same order: one entry per network id",
+		Required: true,
+	}
 )
 
 func main() {
@@ -111,6 +382,35 @@ func main() {
 				&addFakeBridgeFlag,
 				&storeCertificateFlag,
 				&singleCertFlag,
+				&bridgeRPCFlag,
+				&fuzzScenarioFlag,
+				&rpcAddrFlag,
+				&certTransportFlag,
+				&certTransportAddrFlag,
+				&certTransportDirFlag,
+				&certsPerSecondFlag,
+				&burstFlag,
+				&jitterFlag,
+				&metricsAddrFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
+				&allowReorgRecoveryFlag,
+				&auditFileFlag,
+				&auditFileMaxBytesFlag,
+				&signerSourceFlag,
+				&mnemonicFlag,
+				&derivationPathFlag,
+				&seedFlag,
+				&signerIndexFlag,
+				&signerCountFlag,
+				&faultScenarioFileFlag,
+				&resultStreamFlag,
 			},
 		},
 		{
@@ -125,6 +425,37 @@ func main() {
 				&addFakeBridgeFlag,
 				&storeCertificateFlag,
 				&singleCertFlag,
+				&fuzzScenarioFlag,
+				&rpcAddrFlag,
+				&certTransportFlag,
+				&certTransportAddrFlag,
+				&certTransportDirFlag,
+				&certsPerSecondFlag,
+				&burstFlag,
+				&jitterFlag,
+				&metricsAddrFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
+				&allowReorgRecoveryFlag,
+				&auditFileFlag,
+				&auditFileMaxBytesFlag,
+				&signerSourceFlag,
+				&mnemonicFlag,
+				&derivationPathFlag,
+				&seedFlag,
+				&signerIndexFlag,
+				&signerCountFlag,
+				&faultScenarioFileFlag,
+				&resultStreamFlag,
+				&concurrencyFlag,
+				&rateFlag,
+				&durationFlag,
 			},
 		},
 		{
@@ -140,13 +471,153 @@ func main() {
 				&networkIDFlag,
 				&certHeightFlag,
 				&randomGlobalIndexFlag,
+				&bridgeRPCFlag,
+				&workersFlag,
+				&rateFlag,
+				&durationFlag,
+				&metricsAddrFlag,
+				&corpusDirFlag,
+				&keystoreFileFlag,
+				&keystorePasswordFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
+			},
+		},
+		{
+			Name:    "scenario",
+			Aliases: []string{},
+			Usage:   "Generate and send a deterministic batch of certificates described by a scenario file",
+			Action:  runScenario,
+			Flags: []cli.Flag{
+				&urlFlag,
+				&privateKeyFlag,
+				&scenarioFileFlag,
+				&workersFlag,
+				&rateFlag,
+				&metricsAddrFlag,
+				&corpusDirFlag,
+				&keystoreFileFlag,
+				&keystorePasswordFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
+			},
+		},
+		{
+			Name:    "replay",
+			Aliases: []string{},
+			Usage:   "Resend a certificate previously saved to the corpus",
+			Action:  replayCorpusEntry,
+			Flags: []cli.Flag{
+				&urlFlag,
+				&corpusDirFlag,
+				&corpusHashFlag,
+			},
+		},
+		{
+			Name:    "replay-certificates",
+			Aliases: []string{},
+			Usage:   "Re-sign and resubmit a directory of certificates previously saved by valid-certs/invalid-signature-certs, against a (possibly fresh) agglayer",
+			Action:  replayCertificates,
+			Flags: []cli.Flag{
+				&configFileFlag,
+				&disableDefaultConfigVars,
+				&replayDirFlag,
+				&certTransportFlag,
+				&certTransportAddrFlag,
+				&certTransportDirFlag,
+			},
+		},
+		{
+			Name:    "restore-certificate",
+			Aliases: []string{},
+			Usage:   "Roll a certificate back to a certificate_history snapshot recorded before an earlier transition",
+			Action:  restoreCertificate,
+			Flags: []cli.Flag{
+				&configFileFlag,
+				&disableDefaultConfigVars,
+				&restoreCertificateIDFlag,
+				&restoreUpdatedAtFlag,
+			},
+		},
+		{
+			Name:    "multi-network-certs",
+			Aliases: []string{},
+			Usage:   "Generate and send valid certificates for several NetworkIDs in parallel, against the same agglayer",
+			Action:  sendMultiNetworkCerts,
+			Flags: []cli.Flag{
+				&configFileFlag,
+				&disableDefaultConfigVars,
+				&networkIDsFlag,
+				&l2RPCURLsFlag,
+				&emptyCertificateFlag,
+				&addFakeBridgeFlag,
+				&storeCertificateFlag,
+				&singleCertFlag,
+				&fuzzScenarioFlag,
+				&rpcAddrFlag,
+				&certTransportFlag,
+				&certTransportAddrFlag,
+				&certTransportDirFlag,
+				&rateFlag,
+				&certsPerSecondFlag,
+				&burstFlag,
+				&jitterFlag,
+				&durationFlag,
+				&metricsAddrFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
+				&allowReorgRecoveryFlag,
+				&auditFileFlag,
+				&auditFileMaxBytesFlag,
+				&faultScenarioFileFlag,
+				&resultStreamFlag,
+			},
+		},
+		{
+			Name:    "shrink",
+			Aliases: []string{},
+			Usage:   "Minimize a corpus certificate that produced a given agglayer error, keeping only the smallest input that still reproduces it",
+			Action:  shrinkCorpusEntry,
+			Flags: []cli.Flag{
+				&urlFlag,
+				&corpusDirFlag,
+				&corpusHashFlag,
+				&privateKeyFlag,
+				&keystoreFileFlag,
+				&keystorePasswordFlag,
+				&signerURLFlag,
+				&signerAddressFlag,
+				&kmsKeyIDFlag,
+				&signerGRPCAddrFlag,
+				&signerGRPCKeyIDFlag,
+				&signerGRPCTLSCAFlag,
+				&signerGRPCTLSCertFlag,
+				&signerGRPCTLSKeyFlag,
 			},
 		},
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
-		log.Fatal(err)
-		os.Exit(1)
+		log.Error(err)
+		os.Exit(spammererr.ExitCode(err))
 	}
 }
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+
+	"github.com/0xPolygon/cdk"
+	"github.com/0xPolygon/cdk/config"
+	"github.com/0xPolygon/cdk/l1infotreesync"
+	"github.com/0xPolygon/cdk/log"
+	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// buildMultiNetworkSenders dials one L2 client per entry of --l2-rpc-urls
+// and builds one fully independent AggSender per entry, registering each
+// under the matching entry of --network-ids with coordinator. Every sender
+// gets its own freshly generated signing key and its own StoragePath/DBPath
+// (suffixed by network id), so N rollups can be driven concurrently without
+// fighting over the same sqlite-backed storage, while all of them share the
+// same L1 client and l1InfoTreeSync: in agglayer topologies, one L1 can back
+// many rollups, and each rollup is exactly one NetworkID.
+func buildMultiNetworkSenders(
+	ctx *cli.Context,
+	cfg *config.Config,
+	l1Client *ethclient.Client,
+	l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
+	coordinator *spammerAggsender.Coordinator,
+	networkIDs []uint32,
+	l2RPCURLs []string,
+) ([]*spammerAggsender.AggSender, error) {
+	senders := make([]*spammerAggsender.AggSender, 0, len(networkIDs))
+	for i, networkID := range networkIDs {
+		l2RPCURL := l2RPCURLs[i]
+		log.Infof("network %d: dialing L2 client at: %s", networkID, l2RPCURL)
+		l2Client, err := ethclient.Dial(l2RPCURL)
+		if err != nil {
+			return nil, fmt.Errorf("network %d: error dialing L2 client at %s: %w", networkID, l2RPCURL, err)
+		}
+		reorgDetectorL2, errChanL2, err := runReorgDetectorL2(ctx.Context, l2Client, &cfg.ReorgDetectorL2)
+		if err != nil {
+			return nil, fmt.Errorf("network %d: error starting ReorgDetectorL2: %w", networkID, err)
+		}
+		go func(networkID uint32) {
+			if err := <-errChanL2; err != nil {
+				log.Fatalf("network %d: error from ReorgDetectorL2: %v", networkID, err)
+			}
+		}(networkID)
+
+		bridgeSyncCfg := cfg.BridgeL2Sync
+		bridgeSyncCfg.DBPath = fmt.Sprintf("%s.network%d", cfg.BridgeL2Sync.DBPath, networkID)
+		l2BridgeSync, err := runBridgeSyncL2(ctx.Context, bridgeSyncCfg, reorgDetectorL2, l2Client, networkID)
+		if err != nil {
+			return nil, fmt.Errorf("network %d: error starting bridgeSyncL2: %w", networkID, err)
+		}
+
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("network %d: error generating signing key: %w", networkID, err)
+		}
+		log.Infof("network %d wallet address: %s", networkID, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+
+		aggSenderCfg := cfg.AggSender
+		aggSenderCfg.StoragePath = fmt.Sprintf("%s.network%d", cfg.AggSender.StoragePath, networkID)
+
+		sender, err := createAggSender(
+			ctx.Context,
+			aggSenderCfg,
+			l1Client,
+			l1InfoTreeSync,
+			l2BridgeSync,
+			bridgeSyncCfg.DBPath,
+			signer.NewLocalSigner(privateKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("network %d: error creating aggsender: %w", networkID, err)
+		}
+
+		coordinator.Add(networkID, sender)
+		senders = append(senders, sender)
+	}
+
+	return senders, nil
+}
+
+// sendMultiNetworkCerts drives --network-ids in parallel against a single
+// agglayer, one independent AggSender per network fanned out through an
+// aggsender.Coordinator, instead of the single-NetworkID loop every other
+// send command runs. Each network's storage, L2 syncer and signing key are
+// kept fully independent; only the L1 client, l1InfoTreeSync, and the
+// process-wide flags below (rate limit, fault scenario, audit log, result
+// stream) are shared across all of them.
+func sendMultiNetworkCerts(ctx *cli.Context) error {
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	emptyCert := ctx.Bool(emptyCertFlagName)
+	addFakeBridge := ctx.Bool(addFakeBridgeFlagName)
+	storeCertificate := ctx.Bool(storeCertificateFlagName)
+	singleCert := ctx.Bool(singleCertFlagName)
+
+	log.Init(cfg.Log)
+
+	networkIDStrs := ctx.StringSlice(networkIDsFlagName)
+	l2RPCURLs := ctx.StringSlice(l2RPCURLsFlagName)
+	if len(networkIDStrs) == 0 {
+		return fmt.Errorf("multi-network-certs: --network-ids must list at least one network id")
+	}
+	if len(networkIDStrs) != len(l2RPCURLs) {
+		return fmt.Errorf("multi-network-certs: --network-ids (%d entries) and --l2-rpc-urls (%d entries) "+
+			"must have the same length: one L2 RPC URL per network id, in the same order",
+			len(networkIDStrs), len(l2RPCURLs))
+	}
+	networkIDs := make([]uint32, len(networkIDStrs))
+	for i, s := range networkIDStrs {
+		id, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("multi-network-certs: invalid --network-ids entry %q: %w", s, err)
+		}
+		networkIDs[i] = uint32(id)
+	}
+
+	log.Infow("Starting application",
+		"gitRevision", cdk.GitRev,
+		"gitBranch", cdk.GitBranch,
+		"goVersion", runtime.Version(),
+		"built", cdk.BuildDate,
+		"os/arch", fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	)
+
+	urlRPCL1 := cfg.Etherman.URL
+	log.Debugf("dialing L1 client at: %s", urlRPCL1)
+	l1Client, err := ethclient.Dial(urlRPCL1)
+	if err != nil {
+		log.Errorf("failed to create client for L1 using URL: %s. Err:%v", urlRPCL1, err)
+		return err
+	}
+	reorgDetectorL1, errChanL1, err := runReorgDetectorL1(ctx.Context, l1Client, &cfg.ReorgDetectorL1)
+	if err != nil {
+		log.Error("Error from ReorgDetectorL1: ", err)
+		return err
+	}
+	go func() {
+		if err := <-errChanL1; err != nil {
+			log.Fatal("Error from ReorgDetectorL1: ", err)
+		}
+	}()
+
+	l1InfoTreeSync, err := runL1InfoTreeSyncer(ctx.Context, *cfg, l1Client, reorgDetectorL1)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	coordinator := spammerAggsender.NewCoordinator()
+	senders, err := buildMultiNetworkSenders(ctx, cfg, l1Client, l1InfoTreeSync, coordinator, networkIDs, l2RPCURLs)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	certTransport, err := buildCertTransport(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	certSigner, err := buildAggSenderSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	auditLogger, err := buildAuditLogger(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	faultPicker, err := buildFaultPicker(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	resultStream := buildResultStream(ctx)
+
+	for _, sender := range senders {
+		if certTransport != nil {
+			sender.SetTransport(certTransport)
+		}
+		if certSigner != nil {
+			sender.SetSigner(certSigner)
+		}
+		if ctx.Bool(allowReorgRecoveryFlagName) {
+			sender.SetAllowReorgRecovery(true)
+		}
+		if auditLogger != nil {
+			sender.SetAuditLogger(auditLogger)
+		}
+		if faultPicker != nil {
+			// A single shared Picker is intentional: its iteration cap then
+			// bounds the whole run's mutated certificates across every
+			// network, not just one network's share of them.
+			sender.SetFaultScenario(faultPicker)
+		}
+		if resultStream != nil {
+			sender.SetResultStream(resultStream)
+		}
+	}
+
+	applyLoadRateLimit(ctx, senders)
+
+	if ctx.String(rpcAddrFlagName) != "" {
+		log.Warn("rpc-addr is a no-op in multi-network mode: agg_* rpc serves a single aggsender, not a pool of them")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx.Context)
+	defer cancel()
+	awaitLoadDuration(ctx, cancel)
+
+	scenarioID := spammerAggsender.ScenarioID(ctx.String(fuzzScenarioFlagName))
+	coordinator.Start(runCtx, emptyCert, addFakeBridge, storeCertificate, singleCert, scenarioID)
+	waitSignal(nil)
+
+	return nil
+}
@@ -1,34 +1,42 @@
 package main
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"math/big"
-	"strconv"
 	mathrand "math/rand/v2"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/0xPolygon/cdk/agglayer"
 	"github.com/0xPolygon/cdk/aggsender/types"
 	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/bridgerpc"
+	"github.com/ARR552/agglayer_certificate_spammer/corpus"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 )
 
-func genRandomCert(emptyCert, randomGlobalIndex bool, networkID uint, height string) (*agglayer.Certificate, error) {
+func genRandomCert(emptyCert, randomGlobalIndex bool, networkID uint, height string, bridgeRPC *bridgerpc.Client) (*agglayer.Certificate, error) {
 	var (
 		bridgeExits         []*agglayer.BridgeExit
 		importedBridgeExits []*agglayer.ImportedBridgeExit
 		err                 error
 	)
 	if !emptyCert {
-		log.Info("Generating random bridges and claims...")
-		bridgeExits, importedBridgeExits, err = generateBridgesAndClaims(randomGlobalIndex)
+		if bridgeRPC != nil {
+			log.Info("Pulling real bridges and claims from the bridge-RPC...")
+			bridgeExits, importedBridgeExits, err = generateBridgesAndClaimsFromRPC(bridgeRPC, uint32(networkID))
+		} else {
+			log.Info("Generating random bridges and claims...")
+			bridgeExits, importedBridgeExits, err = generateBridgesAndClaims(randomGlobalIndex)
+		}
 		if err != nil {
 			log.Error("error generating bridges and claims. Error: ", err)
 			return nil, err
@@ -90,76 +98,85 @@ func randomAddress() common.Address {
 
 func randomCerts(ctx *cli.Context) error {
 	url := ctx.String(urlFlagName)
-	privateKey := ctx.String(privateKeyFlagName)
 	validSignature := ctx.Bool(validSignatureFlagName)
 	emptyCert := ctx.Bool(emptyCertFlagName)
 	networkID := ctx.Uint(networkIDFlagName)
 	height := ctx.String(certHeightFlagName)
 	randomGlobalIndex := ctx.Bool(randomGlobalIndexFlagName)
+	bridgeRPCURL := ctx.String(bridgeRPCFlagName)
+	workers := ctx.Int(workersFlagName)
+	ratePerSecond := ctx.Float64(rateFlagName)
+	duration := ctx.Duration(durationFlagName)
+	metricsAddr := ctx.String(metricsAddrFlagName)
+	corpusDir := ctx.String(corpusDirFlagName)
 
-	cert, err := genRandomCert(emptyCert, randomGlobalIndex, networkID, height)
-	if err != nil {
-		log.Error(err)
-		return err
+	var bridgeRPCClient *bridgerpc.Client
+	if bridgeRPCURL != "" {
+		bridgeRPCClient = bridgerpc.NewClient(bridgeRPCURL)
 	}
-	var signedCert *agglayer.SignedCertificate
-	if !validSignature {
-		log.Info("Generating random signature...")
-		signedCert = &agglayer.SignedCertificate{
-			Certificate: cert,
-			Signature: &agglayer.Signature{
-				R:         randomHash(),
-				S:         randomHash(),
-				OddParity: mathrand.UintN(2) == 0,
-			},
+
+	if corpusDir != "" {
+		var err error
+		if corpusStore, err = corpus.NewStore(corpusDir); err != nil {
+			log.Error(err)
+			return err
 		}
-	} else {
-		var privKey *ecdsa.PrivateKey
-		if privateKey == "" {
-			privKey, err = crypto.GenerateKey()
-			if err != nil {
-				log.Error(err)
-				return err
-			}
-			log.Info("Random Private Key generated:", hexutil.Encode(crypto.FromECDSA(privKey)))
+	}
 
-			publicKey := privKey.Public()
-			publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-			if !ok {
-				log.Error("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-				return fmt.Errorf("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-			}
-			log.Info("Generated wallet Address:", crypto.PubkeyToAddress(*publicKeyECDSA).Hex())
-			signedCert, err = signCertificate(cert, privKey)
-			if err != nil {
-				log.Error("error signing the certificate. Error: ", err)
-				return err
-			}
-		} else {
-			privKey, err = crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
-			if err != nil {
-				log.Fatal(err)
-			}
-			publicKey := privKey.Public()
-			publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-			if !ok {
-				log.Error("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-				return fmt.Errorf("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
-			}
-			log.Info("Imported wallet Address:", crypto.PubkeyToAddress(*publicKeyECDSA).Hex())
-			signedCert, err = signCertificate(cert, privKey)
-			if err != nil {
-				log.Error("error signing the certificate. Error: ", err)
-				return err
-			}
+	var certSigner signer.CertSigner
+	if validSignature {
+		var err error
+		certSigner, err = buildCertSigner(ctx)
+		if err != nil {
+			log.Error(err)
+			return err
 		}
+		log.Info("Signing wallet Address:", certSigner.Address().Hex())
 	}
-	err = sendCert(url, signedCert)
-	if err != nil {
-		log.Error(err)
-		return err
+
+	source := func() (*agglayer.Certificate, error) {
+		return genRandomCert(emptyCert, randomGlobalIndex, networkID, height, bridgeRPCClient)
 	}
-	return nil
+	sign := func(cert *agglayer.Certificate) (*agglayer.SignedCertificate, error) {
+		if !validSignature {
+			return &agglayer.SignedCertificate{
+				Certificate: cert,
+				Signature: &agglayer.Signature{
+					R:         randomHash(),
+					S:         randomHash(),
+					OddParity: mathrand.UintN(2) == 0,
+				},
+			}, nil
+		}
+		return signCertificate(ctx.Context, cert, certSigner)
+	}
+	send := func(cert *agglayer.SignedCertificate) error {
+		return sendCert(url, cert)
+	}
+
+	if workers <= 1 && ratePerSecond <= 0 && duration <= 0 {
+		// No spamming flags were given: preserve the historical behaviour of
+		// sending exactly one certificate.
+		cert, err := source()
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		signedCert, err := sign(cert)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		return send(signedCert)
+	}
+
+	pool := spammer.New(spammer.Config{
+		Workers:       workers,
+		RatePerSecond: ratePerSecond,
+		Duration:      duration,
+		MetricsAddr:   metricsAddr,
+	})
+	return pool.Run(ctx.Context, source, sign, send)
 }
 
 func sendCert(url string, cert *agglayer.SignedCertificate) error {
@@ -194,49 +211,129 @@ func sendCert(url string, cert *agglayer.SignedCertificate) error {
 	// Send certificate
 	jsonCert, _ := json.Marshal(cert)
 	log.Debugf("%+v\n", string(jsonCert))
-	hash, err := agglayer.NewAggLayerClient(url).SendCertificate(cert)
-	if err != nil {
-		log.Error(err)
-		return err
+	hash, sendErr := agglayer.NewAggLayerClient(url).SendCertificate(cert)
+	recordToCorpus(cert, sendErr)
+	if sendErr != nil {
+		log.Error(sendErr)
+		return sendErr
 	}
 	log.Info("Certificate sent with hash: ", hash.String())
 	return nil
 }
 
-// signCertificate signs a certificate with the sequencer key
-func signCertificate(certificate *agglayer.Certificate, privateKey *ecdsa.PrivateKey) (*agglayer.SignedCertificate, error) {
+// corpusStore, when set, persists every certificate sendCert sends -
+// together with the agglayer's response - to a content-addressed corpus
+// directory so failures can be replayed or shrunk later.
+var corpusStore *corpus.Store
+
+func recordToCorpus(cert *agglayer.SignedCertificate, sendErr error) {
+	if corpusStore == nil {
+		return
+	}
+	entryHash, err := corpusStore.Save(cert, sendErr, spammer.ClassifyAgglayerError(sendErr))
+	if err != nil {
+		log.Errorf("error saving certificate to corpus: %s", err)
+		return
+	}
+	log.Debugf("certificate saved to corpus as %s", entryHash)
+}
+
+// signCertificate signs a certificate with certSigner, whatever backend it
+// is wired to (a local key, a keystore, a remote Clef-style signer, or KMS).
+func signCertificate(
+	ctx context.Context, certificate *agglayer.Certificate, certSigner signer.CertSigner,
+) (*agglayer.SignedCertificate, error) {
 	hashToSign := certificate.HashToSign()
 
-	signature, err := crypto.Sign(hashToSign.Bytes(), privateKey)
+	r, s, v, err := certSigner.SignHash(ctx, hashToSign)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Infof("Signed certificate. sequencer address: %s. New local exit root: %s Hash signed: %s",
-		crypto.PubkeyToAddress(privateKey.PublicKey).String(),
+		certSigner.Address().String(),
 		common.BytesToHash(certificate.NewLocalExitRoot[:]).String(),
 		hashToSign.String(),
 	)
 
-	const signatureSize = 65
-	if len(signature) != signatureSize {
-		return nil, fmt.Errorf("invalid signature size")
-	}
-
-	r := common.BytesToHash(signature[:32])   // First 32 bytes are R
-	s := common.BytesToHash(signature[32:64]) // Next 32 bytes are S
-	isOddParity := signature[64]%2 == 1       //nolint:mnd // Last byte is V
-
 	return &agglayer.SignedCertificate{
 		Certificate: certificate,
 		Signature: &agglayer.Signature{
-			R:         r,
-			S:         s,
-			OddParity: isOddParity,
+			R:         common.Hash(r),
+			S:         common.Hash(s),
+			OddParity: v%2 == 1, //nolint:mnd // v is the recovery id
 		},
 	}, nil
 }
 
+// generateBridgesAndClaimsFromRPC builds BridgeExit/ImportedBridgeExit entries
+// from real bridge events and claim proofs pulled from the bridge-RPC,
+// instead of fabricating them from random bytes. This allows constructing
+// semi-valid certificates: real bridge/claim data paired with other
+// deliberately broken fields (e.g. a forged NewLocalExitRoot).
+func generateBridgesAndClaimsFromRPC(client *bridgerpc.Client, networkID uint32) ([]*agglayer.BridgeExit, []*agglayer.ImportedBridgeExit, error) {
+	const latestBridgesCount = 8
+
+	events, err := client.LatestBridges(networkID, latestBridgesCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching bridges from bridge-rpc: %w", err)
+	}
+
+	bridgeExits := make([]*agglayer.BridgeExit, 0, len(events))
+	importedBridgeExits := make([]*agglayer.ImportedBridgeExit, 0, len(events))
+	for _, event := range events {
+		amount, ok := new(big.Int).SetString(event.Amount, 10) //nolint:mnd // amount is reported as a base-10 string
+		if !ok {
+			return nil, nil, fmt.Errorf("error parsing bridge amount %q for deposit %d", event.Amount, event.DepositCount)
+		}
+
+		bridgeExit := &agglayer.BridgeExit{
+			LeafType: agglayer.LeafType(event.LeafType),
+			TokenInfo: &agglayer.TokenInfo{
+				OriginNetwork:      event.OriginNetwork,
+				OriginTokenAddress: event.OriginAddress,
+			},
+			DestinationNetwork: event.DestinationNetwork,
+			DestinationAddress: event.DestinationAddress,
+			Amount:             amount,
+			IsMetadataHashed:   false,
+			Metadata:           event.Metadata,
+		}
+		bridgeExits = append(bridgeExits, bridgeExit)
+
+		proof, err := client.ClaimProofForDeposit(networkID, event.DepositCount, event.DepositCount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error fetching claim proof for deposit %d: %w", event.DepositCount, err)
+		}
+
+		l1Leaf := &agglayer.L1InfoTreeLeaf{
+			L1InfoTreeIndex: proof.L1InfoTreeIndex,
+			RollupExitRoot:  proof.RollupExitRoot,
+			MainnetExitRoot: proof.MainnetExitRoot,
+			Inner: &agglayer.L1InfoTreeLeafInner{
+				GlobalExitRoot: proof.GlobalExitRoot,
+				BlockHash:      proof.BlockHash,
+				Timestamp:      proof.Timestamp,
+			},
+		}
+
+		importedBridgeExits = append(importedBridgeExits, &agglayer.ImportedBridgeExit{
+			BridgeExit: bridgeExit,
+			ClaimData: &agglayer.ClaimFromMainnnet{
+				ProofLeafMER:     &agglayer.MerkleProof{Root: proof.MainnetExitRoot, Proof: proof.ProofLocalExitRoot},
+				ProofGERToL1Root: &agglayer.MerkleProof{Root: proof.GlobalExitRoot, Proof: proof.ProofRollupExitRoot},
+				L1Leaf:           l1Leaf,
+			},
+			GlobalIndex: &agglayer.GlobalIndex{
+				MainnetFlag: true,
+				LeafIndex:   event.DepositCount,
+			},
+		})
+	}
+
+	return bridgeExits, importedBridgeExits, nil
+}
+
 func generateBridgesAndClaims(randomGlobalIndex bool) ([]*agglayer.BridgeExit, []*agglayer.ImportedBridgeExit, error) {
 	amount, err := rand.Int(rand.Reader, big.NewInt(1000000000000000000))
 	if err != nil {
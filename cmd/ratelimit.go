@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+
+	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
+)
+
+// applyRateLimit configures the send-loop rate limiter from the
+// --certs-per-second/--burst/--jitter flags, and, if --metrics-addr is set,
+// registers and serves its Prometheus collectors.
+func applyRateLimit(ctx *cli.Context, aggsender *spammerAggsender.AggSender) {
+	aggsender.SetRateLimit(
+		ctx.Float64(certsPerSecondFlagName),
+		ctx.Int(burstFlagName),
+		ctx.Duration(jitterFlagName),
+	)
+
+	metricsAddr := ctx.String(metricsAddrFlagName)
+	if metricsAddr == "" {
+		return
+	}
+	reg := prometheus.NewRegistry()
+	aggsender.SetRateMetrics(spammerAggsender.NewRateMetrics(reg))
+	go spammer.ServeMetrics(ctx.Context, metricsAddr, reg)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/0xPolygon/cdk"
+	"github.com/0xPolygon/cdk/common"
+	"github.com/0xPolygon/cdk/config"
+	"github.com/0xPolygon/cdk/etherman"
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// replayCertificates re-signs and resubmits a directory of certificates
+// previously saved by valid-certs/invalid-signature-certs, against whatever
+// agglayer and L2 state the current config points at.
+func replayCertificates(ctx *cli.Context) error {
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	replayDir := ctx.String(replayDirFlagName)
+
+	log.Init(cfg.Log)
+
+	log.Infow("Starting application",
+		"gitRevision", cdk.GitRev,
+		"gitBranch", cdk.GitBranch,
+		"goVersion", runtime.Version(),
+		"built", cdk.BuildDate,
+		"os/arch", fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	)
+
+	urlRPCL1 := cfg.Etherman.URL
+	log.Debugf("dialing L1 client at: %s", urlRPCL1)
+	l1Client, err := ethclient.Dial(urlRPCL1)
+	if err != nil {
+		log.Errorf("failed to create client for L1 using URL: %s. Err:%v", urlRPCL1, err)
+		return err
+	}
+	urlRPCL2 := getL2RPCUrl(cfg)
+	log.Infof("dialing L2 client at: %s", urlRPCL2)
+	l2Client, err := ethclient.Dial(urlRPCL2)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	reorgDetectorL1, errChanL1, err := runReorgDetectorL1(ctx.Context, l1Client, &cfg.ReorgDetectorL1)
+	if err != nil {
+		log.Error("Error from ReorgDetectorL1: ", err)
+		return err
+	}
+	go func() {
+		if err := <-errChanL1; err != nil {
+			log.Fatal("Error from ReorgDetectorL1: ", err)
+		}
+	}()
+
+	reorgDetectorL2, errChanL2, err := runReorgDetectorL2(ctx.Context, l2Client, &cfg.ReorgDetectorL2)
+	if err != nil {
+		log.Error("Error from ReorgDetectorL2: ", err)
+		return err
+	}
+	go func() {
+		if err := <-errChanL2; err != nil {
+			log.Fatal("Error from ReorgDetectorL2: ", err)
+		}
+	}()
+
+	rollupID, err := etherman.GetRollupID(cfg.NetworkConfig.L1Config, cfg.NetworkConfig.L1Config.ZkEVMAddr, l1Client)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	l1InfoTreeSync, err := runL1InfoTreeSyncer(ctx.Context, *cfg, l1Client, reorgDetectorL1)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	l2BridgeSync, err := runBridgeSyncL2(ctx.Context, cfg.BridgeL2Sync, reorgDetectorL2, l2Client, rollupID)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	sequencerPrivateKey, err := common.NewKeyFromKeystore(cfg.AggSender.AggsenderPrivateKey)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	aggsender, err := createAggSender(
+		ctx.Context,
+		cfg.AggSender,
+		l1Client,
+		l1InfoTreeSync,
+		l2BridgeSync,
+		cfg.BridgeL2Sync.DBPath,
+		signer.NewLocalSigner(sequencerPrivateKey),
+	)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	certTransport, err := buildCertTransport(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if certTransport != nil {
+		aggsender.SetTransport(certTransport)
+	}
+
+	if err := aggsender.ReplayCertificates(ctx.Context, replayDir); err != nil {
+		log.Error(err)
+		return err
+	}
+
+	log.Infof("replay of %s completed successfully", replayDir)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/0xPolygon/cdk/aggsender/db"
+	"github.com/0xPolygon/cdk/config"
+	"github.com/0xPolygon/cdk/log"
+	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// restoreCertificate rolls a certificate back to a certificate_history
+// snapshot recorded before an earlier transition (for instance, one made by
+// an AllowReorgRecovery rollback), without needing a full AggSender and its
+// L1/L2 dependencies - only the aggsender storage config is required.
+func restoreCertificate(ctx *cli.Context) error {
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return err
+	}
+	log.Init(cfg.Log)
+
+	certificateID := common.HexToHash(ctx.String(restoreCertificateIDFlagName))
+	updatedAt := uint32(ctx.Uint(restoreUpdatedAtFlagName))
+
+	storage, err := db.NewAggSenderSQLStorage(log.WithFields("module", "restore_certificate"), db.AggSenderSQLStorageConfig{
+		DBPath:                  cfg.AggSender.StoragePath,
+		KeepCertificatesHistory: cfg.AggSender.KeepCertificatesHistory,
+	})
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	history, err := spammerAggsender.ConnectCertificateHistory(cfg.AggSender.StoragePath)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	restored, err := history.Restore(ctx.Context, storage, certificateID, updatedAt)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	log.Infof("restored certificate %s to its snapshot at updated_at %d: %s", certificateID, updatedAt, restored.String())
+	return nil
+}
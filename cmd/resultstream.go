@@ -0,0 +1,19 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+// buildResultStream returns os.Stdout when --result-stream is set, for the
+// caller to install with AggSender.SetResultStream, or nil to leave the
+// stream disabled.
+func buildResultStream(ctx *cli.Context) io.Writer {
+	if !ctx.Bool(resultStreamFlagName) {
+		return nil
+	}
+
+	return os.Stdout
+}
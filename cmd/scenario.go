@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/corpus"
+	"github.com/ARR552/agglayer_certificate_spammer/scenario"
+	"github.com/ARR552/agglayer_certificate_spammer/spammer"
+	"github.com/urfave/cli/v2"
+)
+
+// runScenario loads a scenario file, expands it into a deterministic batch of
+// certificates and sends each of them through the usual signing/sending path,
+// optionally fanning the batch out across a worker pool.
+func runScenario(ctx *cli.Context) error {
+	url := ctx.String(urlFlagName)
+	scenarioFile := ctx.String(scenarioFileFlagName)
+	workers := ctx.Int(workersFlagName)
+	ratePerSecond := ctx.Float64(rateFlagName)
+	metricsAddr := ctx.String(metricsAddrFlagName)
+	corpusDir := ctx.String(corpusDirFlagName)
+
+	if corpusDir != "" {
+		var err error
+		if corpusStore, err = corpus.NewStore(corpusDir); err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	scn, err := scenario.Load(scenarioFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	certSigner, err := buildCertSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	log.Info("Signing wallet Address:", certSigner.Address().Hex())
+
+	generator := scenario.NewGenerator(scn.Seed)
+	certs, err := generator.Expand(scn)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	log.Infof("Expanded scenario %s into %d certificates", scenarioFile, len(certs))
+
+	var next int64 = -1
+	source := func() (*agglayer.Certificate, error) {
+		i := atomic.AddInt64(&next, 1)
+		if int(i) >= len(certs) {
+			return nil, errScenarioDone
+		}
+		return certs[i], nil
+	}
+	sign := func(cert *agglayer.Certificate) (*agglayer.SignedCertificate, error) {
+		return signCertificate(ctx.Context, cert, certSigner)
+	}
+	send := func(cert *agglayer.SignedCertificate) error {
+		return sendCert(url, cert)
+	}
+
+	pool := spammer.New(spammer.Config{
+		Workers:       workers,
+		RatePerSecond: ratePerSecond,
+		MetricsAddr:   metricsAddr,
+	})
+	if err := pool.Run(ctx.Context, source, sign, send); err != nil && !errors.Is(err, errScenarioDone) {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// errScenarioDone signals that every certificate in the expanded scenario has
+// been handed out; it is not a failure.
+var errScenarioDone = errors.New("scenario exhausted")
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/cdk/common"
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// keystorePasswordEnvVar is the fallback source for --keystore-password, so
+// operators don't have to pass a passphrase on the command line.
+const keystorePasswordEnvVar = "KEYSTORE_PASSWORD"
+
+// buildCertSigner resolves a signer.CertSigner from --signer-url,
+// --signer-grpc-addr, --kms-key-id, --keystore-file and --private-key, in
+// that priority order, falling back to a freshly generated ephemeral key
+// when none of them are set.
+func buildCertSigner(ctx *cli.Context) (signer.CertSigner, error) {
+	signerURL := ctx.String(signerURLFlagName)
+	kmsKeyID := ctx.String(kmsKeyIDFlagName)
+	keystoreFile := ctx.String(keystoreFileFlagName)
+	privateKey := ctx.String(privateKeyFlagName)
+
+	grpcAddr := ctx.String(signerGRPCAddrFlagName)
+
+	switch {
+	case signerURL != "":
+		signerAddress := ctx.String(signerAddressFlagName)
+		if signerAddress == "" {
+			return nil, fmt.Errorf("--signer-address is required when using --signer-url")
+		}
+		log.Infof("Signing with remote signer at %s, account %s", signerURL, signerAddress)
+		return signer.NewRemoteSigner(signerURL, ethcommon.HexToAddress(signerAddress)), nil
+
+	case grpcAddr != "":
+		grpcSigner, err := buildGRPCSigner(ctx, grpcAddr)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing with grpc signer at %s, address %s", grpcAddr, grpcSigner.Address().Hex())
+		return grpcSigner, nil
+
+	case kmsKeyID != "":
+		awsCfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error loading AWS config: %w", err)
+		}
+		kmsSigner, err := signer.NewKMSSigner(context.Background(), kms.NewFromConfig(awsCfg), kmsKeyID)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing with KMS key %s, address %s", kmsKeyID, kmsSigner.Address().Hex())
+		return kmsSigner, nil
+
+	case keystoreFile != "":
+		password := ctx.String(keystorePasswordFlagName)
+		if password == "" {
+			password = os.Getenv(keystorePasswordEnvVar)
+		}
+		privKey, err := common.NewKeyFromKeystore(common.KeystoreFileConfig{
+			Path:     keystoreFile,
+			Password: password,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error loading keystore %s: %w", keystoreFile, err)
+		}
+		return signer.NewLocalSigner(privKey), nil
+
+	case privateKey != "":
+		privKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		return signer.NewLocalSigner(privKey), nil
+
+	default:
+		privKey, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Random Private Key generated:", hexutil.Encode(crypto.FromECDSA(privKey)))
+		return signer.NewLocalSigner(privKey), nil
+	}
+}
+
+// buildGRPCSigner dials addr as a signer.GRPCSigner for --signer-grpc-key-id,
+// applying --signer-grpc-tls-ca/--signer-grpc-tls-cert/--signer-grpc-tls-key
+// if set.
+func buildGRPCSigner(ctx *cli.Context, addr string) (*signer.GRPCSigner, error) {
+	keyID := ctx.String(signerGRPCKeyIDFlagName)
+	if keyID == "" {
+		return nil, fmt.Errorf("--%s is required when using --%s", signerGRPCKeyIDFlagName, signerGRPCAddrFlagName)
+	}
+	tlsConfig, err := grpcSignerTLSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewGRPCSigner(context.Background(), addr, keyID, tlsConfig)
+}
+
+// grpcSignerTLSConfig builds a *tls.Config from --signer-grpc-tls-ca (to
+// trust a private CA) and --signer-grpc-tls-cert/--signer-grpc-tls-key (for
+// mTLS), returning nil if none of them are set so the caller dials
+// insecurely.
+func grpcSignerTLSConfig(ctx *cli.Context) (*tls.Config, error) {
+	caFile := ctx.String(signerGRPCTLSCAFlagName)
+	certFile := ctx.String(signerGRPCTLSCertFlagName)
+	keyFile := ctx.String(signerGRPCTLSKeyFlagName)
+
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", signerGRPCTLSCAFlagName, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in --%s", signerGRPCTLSCAFlagName)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("--%s and --%s must be set together", signerGRPCTLSCertFlagName, signerGRPCTLSKeyFlagName)
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
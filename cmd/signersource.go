@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/cdk/log"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// resolveSignerSource builds the initial signer.CertSigner a command should
+// sign certificates with, based on --signer-source:
+//   - "" (default): resolveSignerSource returns (nil, nil), leaving the
+//     caller to fall back to its own existing default (a keystore-loaded key
+//     for valid-certs, a freshly generated ephemeral key for
+//     invalid-signature-certs).
+//   - "mnemonic": derive a deterministic key from --mnemonic and
+//     --derivation-path.
+//   - "seed": derive --signer-count deterministic keys from --seed and use
+//     the one at --signer-index, so a fleet of processes sharing the same
+//     --seed each pick a distinct, reproducible identity.
+//
+// It does not handle --signer-url/--kms-key-id; those are resolved
+// separately by buildAggSenderSigner and applied afterwards via SetSigner,
+// since they override the signer AggSender was constructed with rather than
+// selecting it.
+func resolveSignerSource(ctx *cli.Context) (signer.CertSigner, error) {
+	switch source := ctx.String(signerSourceFlagName); source {
+	case "":
+		return nil, nil
+
+	case "mnemonic":
+		mnemonic := ctx.String(mnemonicFlagName)
+		if mnemonic == "" {
+			return nil, fmt.Errorf("--%s is required when --%s=mnemonic", mnemonicFlagName, signerSourceFlagName)
+		}
+		derivationPath := ctx.String(derivationPathFlagName)
+		mnemonicSigner, err := signer.NewMnemonicSigner(mnemonic, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing with %s", mnemonicSigner.String())
+		return mnemonicSigner, nil
+
+	case "seed":
+		seedHex := ctx.String(seedFlagName)
+		if seedHex == "" {
+			return nil, fmt.Errorf("--%s is required when --%s=seed", seedFlagName, signerSourceFlagName)
+		}
+		seed, err := hexutil.Decode(seedHex)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding --%s: %w", seedFlagName, err)
+		}
+		index := ctx.Int(signerIndexFlagName)
+		count := ctx.Int(signerCountFlagName)
+		if index >= count {
+			return nil, fmt.Errorf("--%s (%d) must be less than --%s (%d)", signerIndexFlagName, index, signerCountFlagName, count)
+		}
+		signers, err := signer.NewDeterministicSigners(seed, count)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Signing with deterministic seed signer %d/%d, address %s", index, count, signers[index].Address().Hex())
+		return signers[index], nil
+
+	default:
+		return nil, fmt.Errorf("unknown --%s %q (expected \"\", \"mnemonic\" or \"seed\")", signerSourceFlagName, source)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	spammerTransport "github.com/ARR552/agglayer_certificate_spammer/transport"
+)
+
+// buildCertTransport selects a CertificateTransport backend from the
+// --cert-transport flag. It returns a nil transport for the default "http"
+// value, leaving the AggSender's built-in HTTPTransport in place.
+func buildCertTransport(ctx *cli.Context) (spammerTransport.CertificateTransport, error) {
+	switch ctx.String(certTransportFlagName) {
+	case "", "http":
+		return nil, nil
+	case "grpc":
+		addr := ctx.String(certTransportAddrFlagName)
+		if addr == "" {
+			return nil, fmt.Errorf("--%s is required for --%s=grpc", certTransportAddrFlagName, certTransportFlagName)
+		}
+		return spammerTransport.NewGRPCTransport(addr)
+	case "file-drop":
+		dir := ctx.String(certTransportDirFlagName)
+		if dir == "" {
+			return nil, fmt.Errorf("--%s is required for --%s=file-drop", certTransportDirFlagName, certTransportFlagName)
+		}
+		return spammerTransport.NewFileDropTransport(dir)
+	default:
+		return nil, fmt.Errorf("unknown --%s %q", certTransportFlagName, ctx.String(certTransportFlagName))
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"os"
 	"os/signal"
@@ -19,6 +18,8 @@ import (
 	"github.com/0xPolygon/cdk/log"
 	"github.com/0xPolygon/cdk/reorgdetector"
 	spammerAggsender "github.com/ARR552/agglayer_certificate_spammer/aggsender"
+	aggsenderrpc "github.com/ARR552/agglayer_certificate_spammer/aggsender/rpc"
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
@@ -134,7 +135,7 @@ func createAggSender(
 	l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
 	l2Syncer *bridgesync.BridgeSync,
 	bridgeDB string,
-	sequencerPrivateKey *ecdsa.PrivateKey,
+	certSigner signer.CertSigner,
 ) (*spammerAggsender.AggSender, error) {
 	logger := log.WithFields("module", "spammer_aggsender")
 	agglayerClient := agglayer.NewAggLayerClient(cfg.AggLayerURL)
@@ -162,7 +163,7 @@ func createAggSender(
 	log.Infof("Starting epochNotifier: %s", epochNotifier.String())
 	go epochNotifier.Start(ctx)
 
-	return spammerAggsender.New(ctx, logger, cfg, agglayerClient, l1InfoTreeSync, l2Syncer, epochNotifier, sequencerPrivateKey, bridgeDB)
+	return spammerAggsender.New(ctx, logger, cfg, agglayerClient, l1InfoTreeSync, l2Syncer, epochNotifier, certSigner, bridgeDB)
 }
 
 func sendValidCerts(ctx *cli.Context) error {
@@ -173,9 +174,15 @@ func sendValidCerts(ctx *cli.Context) error {
 	emptyCert := ctx.Bool(emptyCertFlagName)
 	addFakeBridge := ctx.Bool(addFakeBridgeFlagName)
 	storeCertificate := ctx.Bool(storeCertificateFlagName)
+	singleCert := ctx.Bool(singleCertFlagName)
 
 	log.Init(cfg.Log)
 
+	if ctx.String(bridgeRPCFlagName) != "" {
+		log.Warn("bridge-rpc is a no-op for valid-certs: this command already builds certificates " +
+			"from the real L2 bridge syncer, not from random data")
+	}
+
 	log.Infow("Starting application",
 		"gitRevision", cdk.GitRev,
 		"gitBranch", cdk.GitBranch,
@@ -235,11 +242,19 @@ func sendValidCerts(ctx *cli.Context) error {
 		log.Error(err)
 		return err
 	}
-	sequencerPrivateKey, err := common.NewKeyFromKeystore(cfg.AggSender.AggsenderPrivateKey)
+	initialSigner, err := resolveSignerSource(ctx)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
+	if initialSigner == nil {
+		sequencerPrivateKey, err := common.NewKeyFromKeystore(cfg.AggSender.AggsenderPrivateKey)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		initialSigner = signer.NewLocalSigner(sequencerPrivateKey)
+	}
 	aggsender, err := createAggSender(
 		ctx.Context,
 		cfg.AggSender,
@@ -247,13 +262,63 @@ func sendValidCerts(ctx *cli.Context) error {
 		l1InfoTreeSync,
 		l2BridgeSync,
 		cfg.BridgeL2Sync.DBPath,
-		sequencerPrivateKey,
+		initialSigner,
 	)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
-	go aggsender.Start(ctx.Context, emptyCert, addFakeBridge, storeCertificate)
+	certTransport, err := buildCertTransport(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if certTransport != nil {
+		aggsender.SetTransport(certTransport)
+	}
+
+	certSigner, err := buildAggSenderSigner(ctx)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if certSigner != nil {
+		aggsender.SetSigner(certSigner)
+	}
+
+	if ctx.Bool(allowReorgRecoveryFlagName) {
+		aggsender.SetAllowReorgRecovery(true)
+	}
+
+	if auditLogger, err := buildAuditLogger(ctx); err != nil {
+		log.Error(err)
+		return err
+	} else if auditLogger != nil {
+		aggsender.SetAuditLogger(auditLogger)
+	}
+
+	if faultPicker, err := buildFaultPicker(ctx); err != nil {
+		log.Error(err)
+		return err
+	} else if faultPicker != nil {
+		aggsender.SetFaultScenario(faultPicker)
+	}
+
+	if resultStream := buildResultStream(ctx); resultStream != nil {
+		aggsender.SetResultStream(resultStream)
+	}
+
+	if rpcAddr := ctx.String(rpcAddrFlagName); rpcAddr != "" {
+		rpcServer := aggsenderrpc.NewServer(aggsender)
+		log.Infof("starting agg_* rpc server on %s", rpcAddr)
+		go func() {
+			if err := rpcServer.ListenAndServe(ctx.Context, rpcAddr); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+	applyRateLimit(ctx, aggsender)
+	go aggsender.Start(ctx.Context, emptyCert, addFakeBridge, storeCertificate, singleCert, spammerAggsender.ScenarioID(ctx.String(fuzzScenarioFlagName)))
 	waitSignal(nil)
 
 	return nil
@@ -0,0 +1,174 @@
+package corpus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ARR552/agglayer_certificate_spammer/signer"
+)
+
+// SendFunc resends a candidate certificate and classifies the error it gets
+// back (empty string for a successful send).
+type SendFunc func(*agglayer.SignedCertificate) string
+
+// Shrink iteratively mutates cert towards a minimal input that still
+// reproduces wantCode, by dropping one BridgeExit/ImportedBridgeExit at a
+// time, zeroing merkle proof entries, and collapsing metadata. It keeps the
+// smallest certificate found that still reproduces the same error class as
+// the original. Every mutated candidate is re-signed with certSigner before
+// being sent, since certificate.HashToSign() covers exactly the fields being
+// mutated here and the original Signature would no longer match.
+func Shrink(ctx context.Context, cert *agglayer.SignedCertificate, wantCode string,
+	certSigner signer.CertSigner, send SendFunc) (*agglayer.SignedCertificate, error) {
+	best := cloneCert(cert)
+
+	for {
+		candidate, changed, err := shrinkOnce(ctx, best, wantCode, certSigner, send)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return best, nil
+		}
+		best = candidate
+	}
+}
+
+// shrinkOnce tries every single-step reduction in turn and returns the first
+// one that still reproduces wantCode.
+func shrinkOnce(ctx context.Context, cert *agglayer.SignedCertificate, wantCode string,
+	certSigner signer.CertSigner, send SendFunc) (*agglayer.SignedCertificate, bool, error) {
+	for i := range cert.Certificate.BridgeExits {
+		candidate := cloneCert(cert)
+		candidate.Certificate.BridgeExits = dropBridgeExit(candidate.Certificate.BridgeExits, i)
+		if err := resignCandidate(ctx, certSigner, candidate); err != nil {
+			return nil, false, err
+		}
+		if send(candidate) == wantCode {
+			return candidate, true, nil
+		}
+	}
+
+	for i := range cert.Certificate.ImportedBridgeExits {
+		candidate := cloneCert(cert)
+		candidate.Certificate.ImportedBridgeExits = dropImportedBridgeExit(candidate.Certificate.ImportedBridgeExits, i)
+		if err := resignCandidate(ctx, certSigner, candidate); err != nil {
+			return nil, false, err
+		}
+		if send(candidate) == wantCode {
+			return candidate, true, nil
+		}
+	}
+
+	for i, ibe := range cert.Certificate.ImportedBridgeExits {
+		mainnetClaim, ok := ibe.ClaimData.(*agglayer.ClaimFromMainnnet)
+		if !ok || mainnetClaim.ProofLeafMER == nil {
+			continue
+		}
+		for j := range mainnetClaim.ProofLeafMER.Proof {
+			if mainnetClaim.ProofLeafMER.Proof[j] == (common.Hash{}) {
+				continue
+			}
+			candidate := cloneCert(cert)
+			candidateClaim := candidate.Certificate.ImportedBridgeExits[i].ClaimData.(*agglayer.ClaimFromMainnnet) //nolint:forcetypeassert // checked above
+			candidateClaim.ProofLeafMER.Proof[j] = common.Hash{}
+			if err := resignCandidate(ctx, certSigner, candidate); err != nil {
+				return nil, false, err
+			}
+			if send(candidate) == wantCode {
+				return candidate, true, nil
+			}
+		}
+	}
+
+	if bridgeExitsHaveMetadata(cert) {
+		candidate := cloneCert(cert)
+		for _, be := range candidate.Certificate.BridgeExits {
+			be.Metadata = nil
+		}
+		if err := resignCandidate(ctx, certSigner, candidate); err != nil {
+			return nil, false, err
+		}
+		if send(candidate) == wantCode {
+			return candidate, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// resignCandidate re-signs candidate in place with certSigner: cloneCert
+// carries over the original Signature unchanged, but every mutation above
+// changes a field certificate.HashToSign() covers, so the stale Signature
+// would fail agglayer's check before wantCode is ever reached.
+func resignCandidate(ctx context.Context, certSigner signer.CertSigner, candidate *agglayer.SignedCertificate) error {
+	hashToSign := candidate.Certificate.HashToSign()
+
+	r, s, v, err := certSigner.SignHash(ctx, hashToSign)
+	if err != nil {
+		return fmt.Errorf("error re-signing shrink candidate: %w", err)
+	}
+
+	candidate.Signature = &agglayer.Signature{
+		R:         common.Hash(r),
+		S:         common.Hash(s),
+		OddParity: v%2 == 1,
+	}
+	return nil
+}
+
+func bridgeExitsHaveMetadata(cert *agglayer.SignedCertificate) bool {
+	for _, be := range cert.Certificate.BridgeExits {
+		if len(be.Metadata) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func dropBridgeExit(exits []*agglayer.BridgeExit, index int) []*agglayer.BridgeExit {
+	out := make([]*agglayer.BridgeExit, 0, len(exits)-1)
+	out = append(out, exits[:index]...)
+	return append(out, exits[index+1:]...)
+}
+
+func dropImportedBridgeExit(exits []*agglayer.ImportedBridgeExit, index int) []*agglayer.ImportedBridgeExit {
+	out := make([]*agglayer.ImportedBridgeExit, 0, len(exits)-1)
+	out = append(out, exits[:index]...)
+	return append(out, exits[index+1:]...)
+}
+
+// cloneCert returns a deep-enough copy of cert for mutation during shrinking:
+// the BridgeExits/ImportedBridgeExits slices and the certificate struct
+// itself are copied so mutating the clone never affects the original.
+func cloneCert(cert *agglayer.SignedCertificate) *agglayer.SignedCertificate {
+	certCopy := *cert.Certificate
+
+	certCopy.BridgeExits = make([]*agglayer.BridgeExit, len(cert.Certificate.BridgeExits))
+	for i, be := range cert.Certificate.BridgeExits {
+		beCopy := *be
+		certCopy.BridgeExits[i] = &beCopy
+	}
+
+	certCopy.ImportedBridgeExits = make([]*agglayer.ImportedBridgeExit, len(cert.Certificate.ImportedBridgeExits))
+	for i, ibe := range cert.Certificate.ImportedBridgeExits {
+		ibeCopy := *ibe
+		beCopy := *ibe.BridgeExit
+		ibeCopy.BridgeExit = &beCopy
+		if mainnetClaim, ok := ibe.ClaimData.(*agglayer.ClaimFromMainnnet); ok {
+			claimCopy := *mainnetClaim
+			proofCopy := *mainnetClaim.ProofLeafMER
+			claimCopy.ProofLeafMER = &proofCopy
+			ibeCopy.ClaimData = &claimCopy
+		}
+		certCopy.ImportedBridgeExits[i] = &ibeCopy
+	}
+
+	return &agglayer.SignedCertificate{
+		Certificate: &certCopy,
+		Signature:   cert.Signature,
+	}
+}
@@ -0,0 +1,134 @@
+// Package corpus persists every certificate sent to the agglayer, along with
+// the response it got back, as a content-addressed file on disk. This turns
+// the spammer from a one-shot fuzzer into a regression corpus: any
+// certificate that made the agglayer misbehave can be replayed later, or fed
+// to the shrinker to find the smallest input that still reproduces the same
+// failure.
+package corpus
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	certFileSuffix   = ".json"
+	resultFileSuffix = ".result"
+	dirPerm          = 0o755
+	filePerm         = 0o644
+)
+
+// Result records the outcome of sending a certificate, alongside the
+// certificate file it belongs to.
+type Result struct {
+	Hash            string `json:"hash"`
+	CertificateHash string `json:"certificateHash"`
+	Error           string `json:"error,omitempty"`
+	Code            string `json:"code"`
+}
+
+// Store saves and loads certificates/results under a single directory.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store rooted at dir, creating it if needed.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("error creating corpus dir %s: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Hash returns the content-address of a signed certificate: keccak256 of its
+// canonical JSON encoding.
+func Hash(cert *agglayer.SignedCertificate) (string, error) {
+	raw, err := json.Marshal(cert)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling certificate for hashing: %w", err)
+	}
+	return crypto.Keccak256Hash(raw).Hex(), nil
+}
+
+// Save writes the certificate and its result to <hash>.json/<hash>.result.
+// It returns the hash it was stored under.
+func (s *Store) Save(cert *agglayer.SignedCertificate, sendErr error, code string) (string, error) {
+	hash, err := Hash(cert)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.MarshalIndent(cert, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshalling certificate %s: %w", hash, err)
+	}
+	if err := os.WriteFile(s.certPath(hash), raw, filePerm); err != nil {
+		return "", fmt.Errorf("error writing corpus entry %s: %w", hash, err)
+	}
+
+	result := Result{Hash: hash, CertificateHash: hash, Code: code}
+	if sendErr != nil {
+		result.Error = sendErr.Error()
+	}
+	resultRaw, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshalling result for %s: %w", hash, err)
+	}
+	if err := os.WriteFile(s.resultPath(hash), resultRaw, filePerm); err != nil {
+		return "", fmt.Errorf("error writing corpus result %s: %w", hash, err)
+	}
+
+	return hash, nil
+}
+
+// Load reads back a previously-saved certificate and its result.
+func (s *Store) Load(hash string) (*agglayer.SignedCertificate, *Result, error) {
+	raw, err := os.ReadFile(s.certPath(hash))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading corpus entry %s: %w", hash, err)
+	}
+	var cert agglayer.SignedCertificate
+	if err := json.Unmarshal(raw, &cert); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling corpus entry %s: %w", hash, err)
+	}
+
+	var result Result
+	resultRaw, err := os.ReadFile(s.resultPath(hash))
+	if err == nil {
+		if err := json.Unmarshal(resultRaw, &result); err != nil {
+			return nil, nil, fmt.Errorf("error unmarshalling corpus result %s: %w", hash, err)
+		}
+	}
+
+	return &cert, &result, nil
+}
+
+// List returns the hashes of every certificate currently stored.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing corpus dir %s: %w", s.dir, err)
+	}
+	var hashes []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), certFileSuffix) {
+			continue
+		}
+		hashes = append(hashes, strings.TrimSuffix(entry.Name(), certFileSuffix))
+	}
+	return hashes, nil
+}
+
+func (s *Store) certPath(hash string) string {
+	return filepath.Join(s.dir, hash+certFileSuffix)
+}
+
+func (s *Store) resultPath(hash string) string {
+	return filepath.Join(s.dir, hash+resultFileSuffix)
+}
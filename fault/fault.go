@@ -0,0 +1,75 @@
+// Package fault implements declarative, weighted fault injection for the
+// aggsender send loop.
+//
+// --fuzz-scenario picks a single malformed certificate shape for an entire
+// run. A fault file instead describes a mix of shapes and how often each
+// should come up, so a single run can exercise several of the agglayer's
+// rejection paths (and a share of well-formed certificates) without
+// restarting the process per scenario.
+package fault
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MutationWeight is one entry in a Scenario's weighted mutation list.
+// Mutation is the same string an aggsender.ScenarioID would hold (e.g.
+// "wrong-height-replay", or "" for a well-formed certificate); Weight is its
+// relative share of the mix, not required to sum to 1.
+type MutationWeight struct {
+	Mutation string  `json:"mutation" yaml:"mutation"`
+	Weight   float64 `json:"weight" yaml:"weight"`
+}
+
+// Scenario is the top-level file format consumed by --fault-scenario-file.
+type Scenario struct {
+	// Seed seeds every draw the Picker makes from Mutations. The same seed
+	// and the same file always produce the same sequence of mutations.
+	Seed int64 `json:"seed" yaml:"seed"`
+
+	Mutations []MutationWeight `json:"mutations" yaml:"mutations"`
+
+	// Iterations caps how many mutations a fault-driven run draws before
+	// stopping: one draw per send attempt, including attempts that find
+	// nothing new to certify and so send no certificate. 0 means run until
+	// interrupted, same as the rest of the CLI's duration/count flags.
+	Iterations int `json:"iterations" yaml:"iterations"`
+}
+
+// Load parses a fault scenario file. The format is picked from the file
+// extension: ".yaml"/".yml" is parsed as YAML, anything else as JSON.
+func Load(path string) (*Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fault scenario file %s: %w", path, err)
+	}
+
+	var scn Scenario
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &scn); err != nil {
+			return nil, fmt.Errorf("error parsing fault scenario file %s as yaml: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(raw, &scn); err != nil {
+			return nil, fmt.Errorf("error parsing fault scenario file %s as json: %w", path, err)
+		}
+	}
+
+	if len(scn.Mutations) == 0 {
+		return nil, fmt.Errorf("fault scenario file %s does not define any mutations", path)
+	}
+	for _, m := range scn.Mutations {
+		if m.Weight < 0 {
+			return nil, fmt.Errorf("fault scenario file %s: mutation %q has a negative weight", path, m.Mutation)
+		}
+	}
+
+	return &scn, nil
+}
@@ -0,0 +1,64 @@
+package fault
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// Picker draws mutations from a Scenario's weighted list, one per
+// certificate, using a single seeded PRNG so two runs of the same scenario
+// file pick the same sequence.
+type Picker struct {
+	mu          sync.Mutex
+	rng         *rand.Rand
+	mutations   []MutationWeight
+	totalWeight float64
+	drawn       int
+	iterations  int
+}
+
+// NewPicker builds a Picker over scn.Mutations, seeded by scn.Seed.
+func NewPicker(scn *Scenario) (*Picker, error) {
+	var totalWeight float64
+	for _, m := range scn.Mutations {
+		totalWeight += m.Weight
+	}
+	if totalWeight <= 0 {
+		return nil, fmt.Errorf("fault scenario's mutations must have a positive total weight")
+	}
+
+	return &Picker{
+		//nolint:gosec // reproducibility, not cryptographic use
+		rng:         rand.New(rand.NewSource(scn.Seed)),
+		mutations:   scn.Mutations,
+		totalWeight: totalWeight,
+		iterations:  scn.Iterations,
+	}, nil
+}
+
+// Pick draws the next mutation's name, e.g. "wrong-height-replay" or "" for
+// a well-formed certificate, to be cast to an aggsender.ScenarioID by the
+// caller.
+func (p *Picker) Pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.drawn++
+	target := p.rng.Float64() * p.totalWeight
+	for _, m := range p.mutations {
+		target -= m.Weight
+		if target <= 0 {
+			return m.Mutation
+		}
+	}
+	return p.mutations[len(p.mutations)-1].Mutation
+}
+
+// Done reports whether the Picker has reached its scenario's Iterations
+// cap. An Iterations of 0 means unlimited, so Done never returns true.
+func (p *Picker) Done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.iterations > 0 && p.drawn >= p.iterations
+}
@@ -0,0 +1,241 @@
+package scenario
+
+import (
+	"math/big"
+	mathrand "math/rand/v2"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/aggsender/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Generator expands CertTemplates into concrete certificates, drawing every
+// random value from a single seeded source so a given Scenario always yields
+// the same sequence of certificates.
+type Generator struct {
+	rnd *mathrand.Rand
+}
+
+// NewGenerator returns a Generator whose PRNG is seeded deterministically
+// from seed.
+func NewGenerator(seed int64) *Generator {
+	s := uint64(seed) //nolint:gosec // deterministic seeding, not security sensitive
+	return &Generator{rnd: mathrand.New(mathrand.NewPCG(s, s))}
+}
+
+// Expand builds every certificate described by the scenario, in order.
+func (g *Generator) Expand(scn *Scenario) ([]*agglayer.Certificate, error) {
+	var certs []*agglayer.Certificate
+	for _, tmpl := range scn.Certs {
+		for i := 0; i < tmpl.Count; i++ {
+			cert, err := g.buildCert(tmpl, i)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		}
+	}
+	return certs, nil
+}
+
+func (g *Generator) buildCert(tmpl CertTemplate, index int) (*agglayer.Certificate, error) {
+	var (
+		bridgeExits         []*agglayer.BridgeExit
+		importedBridgeExits []*agglayer.ImportedBridgeExit
+		err                 error
+	)
+	if !tmpl.EmptyCert {
+		bridgeExits, importedBridgeExits, err = g.generateBridgesAndClaims(tmpl, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	meta := types.NewCertificateMetadata(
+		g.rnd.Uint64(),
+		g.rnd.Uint32(),
+		uint32(g.rnd.Uint64()), //nolint:gosec // truncation is fine, it's a deterministic placeholder timestamp
+	)
+
+	return &agglayer.Certificate{
+		NetworkID:           tmpl.NetworkID,
+		Height:              tmpl.HeightStart + uint64(index),
+		PrevLocalExitRoot:   g.randomHash(),
+		NewLocalExitRoot:    g.randomHash(),
+		BridgeExits:         bridgeExits,
+		ImportedBridgeExits: importedBridgeExits,
+		Metadata:            meta.ToHash(),
+	}, nil
+}
+
+func (g *Generator) generateBridgesAndClaims(tmpl CertTemplate, index int) ([]*agglayer.BridgeExit, []*agglayer.ImportedBridgeExit, error) {
+	amount := g.randomAmount()
+
+	bridgeExits := make([]*agglayer.BridgeExit, 0, tmpl.BridgeExitCount)
+	for i := 0; i < tmpl.BridgeExitCount; i++ {
+		bridgeExits = append(bridgeExits, &agglayer.BridgeExit{
+			LeafType: agglayer.LeafType(g.rnd.UintN(2)), //nolint:mnd // 2 known leaf types
+			TokenInfo: &agglayer.TokenInfo{
+				OriginNetwork:      g.rnd.Uint32(),
+				OriginTokenAddress: g.randomAddress(),
+			},
+			DestinationNetwork: g.rnd.Uint32(),
+			DestinationAddress: g.randomAddress(),
+			Amount:             amount,
+			IsMetadataHashed:   true,
+			Metadata:           g.randomHash().Bytes(),
+		})
+	}
+
+	importedBridgeExits := make([]*agglayer.ImportedBridgeExit, 0, tmpl.ImportedBridgeExitCount)
+	for i := 0; i < tmpl.ImportedBridgeExitCount; i++ {
+		mainnetFlag := g.rnd.UintN(2) == 0 //nolint:mnd // coin flip
+		var rollupIndex uint32
+		if tmpl.RandomGlobalIndex || !mainnetFlag {
+			rollupIndex = g.rnd.Uint32()
+		}
+
+		ibe := &agglayer.ImportedBridgeExit{
+			BridgeExit: &agglayer.BridgeExit{
+				LeafType: agglayer.LeafType(g.rnd.UintN(2)), //nolint:mnd // 2 known leaf types
+				TokenInfo: &agglayer.TokenInfo{
+					OriginNetwork:      g.rnd.Uint32(),
+					OriginTokenAddress: g.randomAddress(),
+				},
+				DestinationNetwork: g.rnd.Uint32(),
+				DestinationAddress: g.randomAddress(),
+				Amount:             amount,
+				IsMetadataHashed:   true,
+				Metadata:           g.randomHash().Bytes(),
+			},
+			ClaimData: g.generateClaimData(),
+			GlobalIndex: &agglayer.GlobalIndex{
+				MainnetFlag: mainnetFlag,
+				RollupIndex: rollupIndex,
+				LeafIndex:   g.rnd.Uint32(),
+			},
+		}
+
+		if i == 0 {
+			if override, ok := tmpl.GlobalIndexOverrides[index]; ok {
+				ibe.GlobalIndex = &agglayer.GlobalIndex{
+					MainnetFlag: override.MainnetFlag,
+					RollupIndex: override.RollupIndex,
+					LeafIndex:   override.LeafIndex,
+				}
+			}
+			if containsInt(tmpl.MutateProofLeafMER, index) {
+				if _, ok := ibe.ClaimData.(*agglayer.ClaimFromMainnnet); !ok {
+					mainnet := g.generateMainnetClaim()
+					ibe.ClaimData = &mainnet
+				}
+				g.mutateProofLeafMER(ibe)
+			}
+		}
+
+		importedBridgeExits = append(importedBridgeExits, ibe)
+	}
+
+	return bridgeExits, importedBridgeExits, nil
+}
+
+// mutateProofLeafMER corrupts the ClaimFromMainnnet.ProofLeafMER of an
+// imported bridge exit so it no longer matches any real merkle path, forcing
+// the agglayer to reject the certificate on proof validation.
+func (g *Generator) mutateProofLeafMER(ibe *agglayer.ImportedBridgeExit) {
+	mainnetClaim, ok := ibe.ClaimData.(*agglayer.ClaimFromMainnnet)
+	if !ok {
+		return
+	}
+	mainnetClaim.ProofLeafMER.Root = g.randomHash()
+	mainnetClaim.ProofLeafMER.Proof[0] = g.randomHash()
+}
+
+func (g *Generator) generateClaimData() agglayer.Claim {
+	if g.rnd.UintN(2) == 0 { //nolint:mnd // coin flip
+		rollup := g.generateRollupClaim()
+		return &rollup
+	}
+	mainnet := g.generateMainnetClaim()
+	return &mainnet
+}
+
+func (g *Generator) generateMainnetClaim() agglayer.ClaimFromMainnnet {
+	mainnet := agglayer.ClaimFromMainnnet{
+		ProofLeafMER:     &agglayer.MerkleProof{Root: g.randomHash()},
+		ProofGERToL1Root: &agglayer.MerkleProof{Root: g.randomHash()},
+		L1Leaf: &agglayer.L1InfoTreeLeaf{
+			L1InfoTreeIndex: g.rnd.Uint32(),
+			RollupExitRoot:  g.randomHash(),
+			MainnetExitRoot: g.randomHash(),
+			Inner: &agglayer.L1InfoTreeLeafInner{
+				GlobalExitRoot: g.randomHash(),
+				BlockHash:      g.randomHash(),
+				Timestamp:      g.rnd.Uint64(),
+			},
+		},
+	}
+	const merkleProofLevels = 32
+	for i := 0; i < merkleProofLevels; i++ {
+		mainnet.ProofLeafMER.Proof[i] = g.randomHash()
+		mainnet.ProofGERToL1Root.Proof[i] = g.randomHash()
+	}
+	return mainnet
+}
+
+func (g *Generator) generateRollupClaim() agglayer.ClaimFromRollup {
+	rollup := agglayer.ClaimFromRollup{
+		ProofLeafLER:     &agglayer.MerkleProof{Root: g.randomHash()},
+		ProofLERToRER:    &agglayer.MerkleProof{Root: g.randomHash()},
+		ProofGERToL1Root: &agglayer.MerkleProof{Root: g.randomHash()},
+		L1Leaf: &agglayer.L1InfoTreeLeaf{
+			L1InfoTreeIndex: g.rnd.Uint32(),
+			RollupExitRoot:  g.randomHash(),
+			MainnetExitRoot: g.randomHash(),
+			Inner: &agglayer.L1InfoTreeLeafInner{
+				GlobalExitRoot: g.randomHash(),
+				BlockHash:      g.randomHash(),
+				Timestamp:      g.rnd.Uint64(),
+			},
+		},
+	}
+	const merkleProofLevels = 32
+	for i := 0; i < merkleProofLevels; i++ {
+		rollup.ProofLeafLER.Proof[i] = g.randomHash()
+		rollup.ProofLERToRER.Proof[i] = g.randomHash()
+		rollup.ProofGERToL1Root.Proof[i] = g.randomHash()
+	}
+	return rollup
+}
+
+func (g *Generator) randomHash() common.Hash {
+	var b [32]byte
+	for i := range b {
+		b[i] = byte(g.rnd.UintN(256)) //nolint:mnd // byte range
+	}
+	return common.BytesToHash(b[:])
+}
+
+func (g *Generator) randomAddress() common.Address {
+	var b [20]byte
+	for i := range b {
+		b[i] = byte(g.rnd.UintN(256)) //nolint:mnd // byte range
+	}
+	return common.BytesToAddress(b[:])
+}
+
+// randomAmount draws a value in [0, 1e18) from g.rnd, the same seeded source
+// every other random draw in this file uses, so a scenario's Amount is
+// reproducible run to run like the rest of the certificate.
+func (g *Generator) randomAmount() *big.Int {
+	return new(big.Int).SetUint64(g.rnd.Uint64N(1000000000000000000)) //nolint:mnd // 1e18, matches the old crypto/rand upper bound
+}
+
+func containsInt(haystack []int, needle int) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
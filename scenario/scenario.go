@@ -0,0 +1,89 @@
+// Package scenario implements declarative, reproducible certificate generation.
+//
+// Instead of relying on the fully-random flags exposed by the CLI (--empty-cert,
+// --network-id, --height, --random-global-index, ...), a scenario file describes
+// a batch of certificates up front: how many, at which heights, for which
+// network, with how many bridge/claim exits, and which of them should carry a
+// deliberately malformed field. Because every PRNG draw made while expanding a
+// scenario comes from a single seeded source, two runs of the same file always
+// produce byte-identical certificates, which makes agglayer invariant failures
+// reproducible in CI instead of one-off.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalIndexOverride forces the GlobalIndex of an imported bridge exit to a
+// specific (and possibly invalid) value instead of a generated one.
+type GlobalIndexOverride struct {
+	MainnetFlag bool   `json:"mainnetFlag" yaml:"mainnetFlag"`
+	RollupIndex uint32 `json:"rollupIndex" yaml:"rollupIndex"`
+	LeafIndex   uint32 `json:"leafIndex" yaml:"leafIndex"`
+}
+
+// CertTemplate describes a run of `Count` certificates for a single network,
+// starting at `HeightStart` and incrementing the height by one per certificate.
+type CertTemplate struct {
+	NetworkID uint32 `json:"networkId" yaml:"networkId"`
+
+	HeightStart uint64 `json:"heightStart" yaml:"heightStart"`
+	Count       int    `json:"count" yaml:"count"`
+
+	EmptyCert               bool `json:"emptyCert" yaml:"emptyCert"`
+	BridgeExitCount         int  `json:"bridgeExitCount" yaml:"bridgeExitCount"`
+	ImportedBridgeExitCount int  `json:"importedBridgeExitCount" yaml:"importedBridgeExitCount"`
+	RandomGlobalIndex       bool `json:"randomGlobalIndex" yaml:"randomGlobalIndex"`
+
+	// GlobalIndexOverrides maps the index of a certificate within this
+	// template's run (0-based) to the GlobalIndex that must be forced on its
+	// first imported bridge exit.
+	GlobalIndexOverrides map[int]GlobalIndexOverride `json:"globalIndexOverrides,omitempty" yaml:"globalIndexOverrides,omitempty"`
+
+	// MutateProofLeafMER lists certificate indexes (within this template's
+	// run) whose first imported bridge exit must get a corrupted
+	// ClaimFromMainnnet.ProofLeafMER, to exercise the agglayer's merkle proof
+	// validation.
+	MutateProofLeafMER []int `json:"mutateProofLeafMer,omitempty" yaml:"mutateProofLeafMer,omitempty"`
+}
+
+// Scenario is the top-level file format consumed by the `scenario` subcommand.
+type Scenario struct {
+	// Seed seeds every PRNG draw made while expanding the scenario. The same
+	// seed and the same file always produce the same certificates.
+	Seed  int64          `json:"seed" yaml:"seed"`
+	Certs []CertTemplate `json:"certs" yaml:"certs"`
+}
+
+// Load parses a scenario file. The format is picked from the file extension:
+// ".yaml"/".yml" is parsed as YAML, anything else as JSON.
+func Load(path string) (*Scenario, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading scenario file %s: %w", path, err)
+	}
+
+	var scn Scenario
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &scn); err != nil {
+			return nil, fmt.Errorf("error parsing scenario file %s as yaml: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(raw, &scn); err != nil {
+			return nil, fmt.Errorf("error parsing scenario file %s as json: %w", path, err)
+		}
+	}
+
+	if len(scn.Certs) == 0 {
+		return nil, fmt.Errorf("scenario file %s does not define any certs", path)
+	}
+
+	return &scn, nil
+}
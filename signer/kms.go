@@ -0,0 +1,109 @@
+package signer
+
+import (
+	"context"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KMSSigner signs through an AWS KMS asymmetric ECC_SECG_P256K1 key, so the
+// sequencer private key never leaves KMS. The public key and derived address
+// are fetched once, at construction time.
+type KMSSigner struct {
+	client  *kms.Client
+	keyID   string
+	address common.Address
+}
+
+// NewKMSSigner fetches keyID's public key from KMS and derives the address
+// it signs as.
+func NewKMSSigner(ctx context.Context, client *kms.Client, keyID string) (*KMSSigner, error) {
+	pub, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching KMS public key %s: %w", keyID, err)
+	}
+	pubKey, err := crypto.UnmarshalPubkey(pub.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KMS public key %s: %w", keyID, err)
+	}
+	return &KMSSigner{
+		client:  client,
+		keyID:   keyID,
+		address: crypto.PubkeyToAddress(*pubKey),
+	}, nil
+}
+
+// Address returns the address derived from the KMS key's public key.
+func (s *KMSSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash asks KMS to produce an ECDSA_SHA_256 digest signature over hash,
+// then recovers the recovery id by trying both parities against the known
+// address, since KMS does not return one.
+func (s *KMSSigner) SignHash(ctx context.Context, hash common.Hash) (r, sig [32]byte, v byte, err error) {
+	out, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          hash.Bytes(),
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return r, sig, 0, fmt.Errorf("error signing with KMS key %s: %w", s.keyID, err)
+	}
+
+	rBig, sBig, err := unmarshalDERSignature(out.Signature)
+	if err != nil {
+		return r, sig, 0, err
+	}
+	sBig = canonicalizeS(sBig)
+	rBig.FillBytes(r[:])
+	sBig.FillBytes(sig[:])
+
+	const parityBitCount = 2
+	for parity := byte(0); parity < parityBitCount; parity++ {
+		candidate := append(append(append([]byte{}, r[:]...), sig[:]...), parity)
+		pubKey, recoverErr := crypto.SigToPub(hash.Bytes(), candidate)
+		if recoverErr == nil && crypto.PubkeyToAddress(*pubKey) == s.address {
+			return r, sig, parity, nil
+		}
+	}
+	return r, sig, 0, fmt.Errorf("could not recover a valid parity bit for KMS signature on key %s", s.keyID)
+}
+
+// secp256k1HalfOrder is half the secp256k1 curve order, the threshold crypto.Sign's
+// local-key path already enforces: any s above it has a low-S equivalent
+// (order - s) that recovers to the same public key under the flipped parity.
+var secp256k1HalfOrder = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// canonicalizeS folds s into the curve's lower half if needed. AWS KMS's
+// ECDSA_SHA_256 signatures aren't guaranteed low-S the way crypto.Sign's are,
+// so without this roughly half of KMS-backed signatures would be rejected by
+// a verifier enforcing the canonical form. The recovery-id search in SignHash tries
+// both parities against whichever s comes out of this, so it still finds the
+// matching one.
+func canonicalizeS(s *big.Int) *big.Int {
+	if s.Cmp(secp256k1HalfOrder) > 0 {
+		return new(big.Int).Sub(crypto.S256().Params().N, s)
+	}
+	return s
+}
+
+// unmarshalDERSignature parses the ASN.1 DER-encoded (r, s) pair KMS returns.
+func unmarshalDERSignature(der []byte) (r, s *big.Int, err error) {
+	var sig struct {
+		R *big.Int
+		S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, nil, fmt.Errorf("error parsing DER signature: %w", err)
+	}
+	return sig.R, sig.S, nil
+}
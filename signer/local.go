@@ -0,0 +1,49 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LocalSigner signs with an in-memory ECDSA private key, whether it was
+// generated on the fly, passed as a raw hex string, or decrypted from a
+// Web3 Secret Storage keystore file.
+type LocalSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewLocalSigner wraps an already-loaded private key.
+func NewLocalSigner(privateKey *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Address returns the address derived from the wrapped private key.
+func (ls *LocalSigner) Address() common.Address {
+	return ls.address
+}
+
+// SignHash signs hash with the wrapped private key. ctx is unused: signing
+// with an in-memory key never blocks.
+func (ls *LocalSigner) SignHash(_ context.Context, hash common.Hash) (r, s [32]byte, v byte, err error) {
+	signature, err := crypto.Sign(hash.Bytes(), ls.privateKey)
+	if err != nil {
+		return r, s, 0, err
+	}
+
+	const signatureSize = 65
+	if len(signature) != signatureSize {
+		return r, s, 0, fmt.Errorf("invalid signature size")
+	}
+
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	return r, s, signature[64], nil
+}
@@ -0,0 +1,105 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+// bip32MasterKeySalt is the fixed HMAC key BIP-32 uses to derive a wallet's
+// master key and chain code from its seed bytes.
+const bip32MasterKeySalt = "Bitcoin seed"
+
+// MnemonicSigner signs with a private key deterministically derived from a
+// mnemonic phrase and a derivation path, so the same (mnemonic, path) pair
+// always reproduces the same signer address across runs - useful for
+// replaying a fixed fleet of fake aggregators against regression tests.
+//
+// Derivation here reuses BIP-32's master-key step (HMAC-SHA512 keyed by
+// "Bitcoin seed") to turn the mnemonic into a key/chain-code pair, then
+// walks derivationPath by HMAC-chaining each path component into the chain
+// code. This is NOT full BIP-32 CKDpriv (real non-hardened child derivation
+// combines the parent key with secp256k1 point arithmetic, not a second
+// HMAC), so keys produced here are deterministic and distinct per path but
+// not compatible with a real HD wallet holding the same mnemonic. It also
+// does not validate mnemonic against the BIP-39 wordlist/checksum; any
+// non-empty string is accepted as raw key material.
+type MnemonicSigner struct {
+	*LocalSigner
+	mnemonic string
+	path     string
+}
+
+// NewMnemonicSigner derives a signing key from mnemonic and derivationPath
+// (e.g. "m/44'/60'/0'/0/0"). An empty mnemonic is rejected; an empty path
+// derives directly from the mnemonic's master key.
+func NewMnemonicSigner(mnemonic, derivationPath string) (*MnemonicSigner, error) {
+	if mnemonic == "" {
+		return nil, fmt.Errorf("mnemonic must not be empty")
+	}
+
+	key, chainCode := bip32MasterKey(mnemonic)
+	for _, component := range splitDerivationPath(derivationPath) {
+		key, chainCode = deriveChild(key, chainCode, component)
+	}
+
+	privateKey, err := ecdsaKeyFromMaterial(key)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key for mnemonic/path %q: %w", derivationPath, err)
+	}
+
+	return &MnemonicSigner{
+		LocalSigner: NewLocalSigner(privateKey),
+		mnemonic:    mnemonic,
+		path:        derivationPath,
+	}, nil
+}
+
+// String identifies the signer by its derivation path and address, never
+// the mnemonic itself.
+func (ms *MnemonicSigner) String() string {
+	return fmt.Sprintf("mnemonic-derived signer path=%q address=%s", ms.path, ms.Address().Hex())
+}
+
+// bip32MasterKey computes BIP-32's master key and chain code from seed
+// material (here, the raw mnemonic bytes rather than a PBKDF2-stretched
+// BIP-39 seed - see MnemonicSigner's doc comment).
+func bip32MasterKey(seed string) (key, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte(bip32MasterKeySalt))
+	mac.Write([]byte(seed))
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// deriveChild folds one derivation path component into (key, chainCode),
+// producing the next level's key and chain code.
+func deriveChild(key, chainCode []byte, component string) (childKey, childChainCode []byte) {
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(key)
+	mac.Write([]byte(component))
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// splitDerivationPath splits a path like "m/44'/60'/0'/0/0" into its
+// components after the leading "m", preserving each component verbatim
+// (including a trailing ' for hardened indices) since it is only ever used
+// as HMAC input here, never as a numeric index.
+func splitDerivationPath(path string) []string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) > 0 && (parts[0] == "m" || parts[0] == "M") {
+		parts = parts[1:]
+	}
+	components := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p != "" {
+			components = append(components, p)
+		}
+	}
+	return components
+}
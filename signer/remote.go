@@ -0,0 +1,106 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const remoteSignerTimeout = 30 * time.Second
+
+// RemoteSigner signs hashes through a Clef-compatible account_signData
+// JSON-RPC endpoint, reached over HTTP(S) or a Unix domain socket, so the
+// signing key never has to leave the operator's signer process.
+type RemoteSigner struct {
+	httpClient *http.Client
+	url        string
+	account    common.Address
+}
+
+// NewRemoteSigner dials a Clef-style signer for account. rawURL may be an
+// http(s):// URL or a unix:///path/to/clef.ipc socket path.
+func NewRemoteSigner(rawURL string, account common.Address) *RemoteSigner {
+	client := &http.Client{Timeout: remoteSignerTimeout}
+	if socketPath, ok := strings.CutPrefix(rawURL, "unix://"); ok {
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		rawURL = "http://unix"
+	}
+	return &RemoteSigner{httpClient: client, url: rawURL, account: account}
+}
+
+// Address returns the account this signer was configured to sign for.
+func (s *RemoteSigner) Address() common.Address {
+	return s.account
+}
+
+type rpcSignRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcSignResponse struct {
+	Result hexutil.Bytes `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SignHash asks the remote signer to sign hash via account_signData, using
+// the "data/plain" content type since agglayer hashes are already digests.
+// ctx bounds the HTTP call so a caller can cancel or time out a hung signer.
+func (s *RemoteSigner) SignHash(ctx context.Context, hash common.Hash) (r, sig [32]byte, v byte, err error) {
+	const signRequestID = 1
+	req := rpcSignRequest{
+		JSONRPC: "2.0",
+		ID:      signRequestID,
+		Method:  "account_signData",
+		Params:  []interface{}{"data/plain", s.account, hexutil.Encode(hash.Bytes())},
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return r, sig, 0, fmt.Errorf("error marshalling remote signer request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return r, sig, 0, fmt.Errorf("error building remote signer request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return r, sig, 0, fmt.Errorf("error calling remote signer: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp rpcSignResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return r, sig, 0, fmt.Errorf("error decoding remote signer response: %w", err)
+	}
+	if resp.Error != nil {
+		return r, sig, 0, fmt.Errorf("remote signer error: %s", resp.Error.Message)
+	}
+
+	const signatureSize = 65
+	if len(resp.Result) != signatureSize {
+		return r, sig, 0, fmt.Errorf("invalid signature size from remote signer")
+	}
+	copy(r[:], resp.Result[:32])
+	copy(sig[:], resp.Result[32:64])
+	return r, sig, resp.Result[64], nil
+}
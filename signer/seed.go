@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NewDeterministicSigners derives n distinct signers from seed, such that
+// the same seed always reproduces the same n addresses in the same order.
+// This is meant for replaying a reproducible fleet of fake aggregators:
+// run the same seed across a fleet, each instance picking its own index out
+// of the n signers returned here.
+func NewDeterministicSigners(seed []byte, n int) ([]*LocalSigner, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
+	}
+
+	signers := make([]*LocalSigner, n)
+	for i := 0; i < n; i++ {
+		material := hmacIndexed(seed, uint32(i)) //nolint:gosec // index, not a security boundary
+		privateKey, err := ecdsaKeyFromMaterial(material)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving signer %d from seed: %w", i, err)
+		}
+		signers[i] = NewLocalSigner(privateKey)
+	}
+	return signers, nil
+}
+
+// hmacIndexed derives 32 bytes of key material unique to index i from seed,
+// via HMAC-SHA256(seed, i).
+func hmacIndexed(seed []byte, i uint32) []byte {
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], i)
+
+	mac := hmac.New(sha256.New, seed)
+	mac.Write(indexBytes[:])
+	return mac.Sum(nil)
+}
+
+// ecdsaKeyFromMaterial reduces 32 bytes of key material modulo N-1 (N being
+// the secp256k1 curve order) and adds one, to get a valid, non-zero private
+// scalar in [1, N-1], then builds an
+// *ecdsa.PrivateKey from it. Used to turn arbitrary deterministic byte
+// strings (an HMAC output, a derived BIP-32-ish key) into a usable signing
+// key, since neither is guaranteed to already land in the curve's valid
+// scalar range.
+func ecdsaKeyFromMaterial(material []byte) (*ecdsa.PrivateKey, error) {
+	curve := crypto.S256()
+	d := new(big.Int).SetBytes(material)
+	d.Mod(d, new(big.Int).Sub(curve.Params().N, big.NewInt(1)))
+	d.Add(d, big.NewInt(1)) // shift into [1, N-1]
+
+	privateKeyBytes := make([]byte, 32) //nolint:mnd
+	d.FillBytes(privateKeyBytes)
+
+	return crypto.ToECDSA(privateKeyBytes)
+}
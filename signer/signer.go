@@ -0,0 +1,18 @@
+// Package signer abstracts over where a certificate's signing key lives, so
+// callers can sign with a raw private key, a Web3 Secret Storage keystore, a
+// remote Clef-style signer, or a KMS-backed key without caring which.
+package signer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CertSigner signs a 32-byte hash and reports the address it signs as.
+// ctx is threaded through so a backend that calls out over the network
+// (RemoteSigner, KMSSigner) can be cancelled and its latency observed.
+type CertSigner interface {
+	SignHash(ctx context.Context, hash common.Hash) (r, s [32]byte, v byte, err error)
+	Address() common.Address
+}
@@ -0,0 +1,101 @@
+package spammer
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors instrumenting the worker pool.
+type Metrics struct {
+	certsSentTotal    *prometheus.CounterVec
+	sendLatency       prometheus.Histogram
+	signingLatency    prometheus.Histogram
+	agglayerErrsTotal *prometheus.CounterVec
+}
+
+// NewMetrics registers the spammer collectors on the given registerer.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	factory := promauto.With(reg)
+	return &Metrics{
+		certsSentTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "certs_sent_total",
+			Help: "Total number of certificates sent, labeled by result (ok/error).",
+		}, []string{"result"}),
+		sendLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "send_latency_seconds",
+			Help: "Latency of agglayer.SendCertificate calls.",
+		}),
+		signingLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "signing_latency_seconds",
+			Help: "Latency of certificate signing.",
+		}),
+		agglayerErrsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "agglayer_error_total",
+			Help: "Total number of agglayer errors, labeled by classified code.",
+		}, []string{"code"}),
+	}
+}
+
+// ServeMetrics starts a blocking HTTP /metrics listener on addr. Meant to be
+// run in its own goroutine; it returns when ctx is done or the listener
+// fails.
+func ServeMetrics(ctx context.Context, addr string, reg prometheus.Gatherer) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second} //nolint:mnd // sane default
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	log.Infof("metrics listening on %s", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Errorf("metrics server stopped unexpectedly: %s", err)
+	}
+}
+
+// ClassifyAgglayerError buckets an error returned by
+// agglayer.AgglayerClientInterface.SendCertificate into a small set of codes
+// so load tests can quickly tell which invariant is tripping.
+func ClassifyAgglayerError(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "height"):
+		return "height_mismatch"
+	case strings.Contains(msg, "nonce"):
+		return "nonce_mismatch"
+	case strings.Contains(msg, "signature"):
+		return "invalid_signature"
+	case strings.Contains(msg, "proof"):
+		return "invalid_proof"
+	case strings.Contains(msg, "connection"), strings.Contains(msg, "timeout"), strings.Contains(msg, "eof"):
+		return "network_error"
+	default:
+		return "unknown"
+	}
+}
+
+func (m *Metrics) observeSend(start time.Time, err error) {
+	m.sendLatency.Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.certsSentTotal.WithLabelValues("error").Inc()
+		m.agglayerErrsTotal.WithLabelValues(ClassifyAgglayerError(err)).Inc()
+		return
+	}
+	m.certsSentTotal.WithLabelValues("ok").Inc()
+}
+
+func (m *Metrics) observeSigning(start time.Time) {
+	m.signingLatency.Observe(time.Since(start).Seconds())
+}
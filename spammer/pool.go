@@ -0,0 +1,164 @@
+// Package spammer runs a pool of worker goroutines that generate, sign and
+// send certificates concurrently, instead of the single blocking
+// generate-sign-send call each CLI subcommand used to perform. Throughput is
+// capped with a token-bucket rate limiter and every stage is instrumented
+// with Prometheus metrics so a load test can tell, at a glance, which
+// agglayer invariant a given run is tripping.
+package spammer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/0xPolygon/cdk/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// CertSource produces the next certificate template to sign and send. It is
+// called concurrently by every worker and must be safe for concurrent use.
+type CertSource func() (*agglayer.Certificate, error)
+
+// Signer signs a certificate template.
+type Signer func(*agglayer.Certificate) (*agglayer.SignedCertificate, error)
+
+// Sender sends an already-signed certificate to the agglayer.
+type Sender func(*agglayer.SignedCertificate) error
+
+// Config configures the worker pool.
+type Config struct {
+	// Workers is the number of concurrent goroutines pulling from the
+	// CertSource. Defaults to 1 if <= 0.
+	Workers int
+	// RatePerSecond caps the aggregate number of certs/sec sent across all
+	// workers. 0 means unlimited.
+	RatePerSecond float64
+	// Duration bounds how long Run keeps spawning work. 0 means run until
+	// ctx is cancelled or the CertSource is exhausted.
+	Duration time.Duration
+	// MetricsAddr, if set, serves Prometheus metrics on this address for the
+	// duration of the run.
+	MetricsAddr string
+}
+
+// Pool runs Config.Workers goroutines pulling certificates from a CertSource,
+// signing and sending them, under an optional rate limit.
+type Pool struct {
+	cfg     Config
+	metrics *Metrics
+	reg     *prometheus.Registry
+}
+
+// New returns a Pool ready to Run.
+func New(cfg Config) *Pool {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	reg := prometheus.NewRegistry()
+	return &Pool{
+		cfg:     cfg,
+		metrics: NewMetrics(reg),
+		reg:     reg,
+	}
+}
+
+// Run drives the pool until ctx is cancelled, Config.Duration elapses, or
+// source returns an error (which cancels every other worker and is returned).
+func (p *Pool) Run(ctx context.Context, source CertSource, sign Signer, send Sender) error {
+	if p.cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.cfg.Duration)
+		defer cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if p.cfg.MetricsAddr != "" {
+		go ServeMetrics(ctx, p.cfg.MetricsAddr, p.reg)
+	}
+
+	var limiter *rate.Limiter
+	if p.cfg.RatePerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(p.cfg.RatePerSecond), maxInt(1, int(p.cfg.RatePerSecond)))
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() { firstErr = err })
+		cancel()
+	}
+
+	for w := 0; w < p.cfg.Workers; w++ {
+		wg.Add(1)
+		workerID := w
+		go func() {
+			defer wg.Done()
+			p.worker(ctx, workerID, limiter, source, sign, send, fail)
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+func (p *Pool) worker(
+	ctx context.Context,
+	id int,
+	limiter *rate.Limiter,
+	source CertSource,
+	sign Signer,
+	send Sender,
+	fail func(error),
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+		}
+
+		cert, err := source()
+		if err != nil {
+			log.Errorf("worker %d: error generating certificate: %s", id, err)
+			fail(fmt.Errorf("error generating certificate: %w", err))
+			return
+		}
+
+		signStart := time.Now()
+		signedCert, err := sign(cert)
+		p.metrics.observeSigning(signStart)
+		if err != nil {
+			log.Errorf("worker %d: error signing certificate: %s", id, err)
+			fail(fmt.Errorf("error signing certificate: %w", err))
+			return
+		}
+
+		sendStart := time.Now()
+		err = send(signedCert)
+		p.metrics.observeSend(sendStart, err)
+		if err != nil {
+			log.Warnf("worker %d: error sending certificate (code: %s): %s", id, ClassifyAgglayerError(err), err)
+		}
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+// Package spammererr gives the startup errors raised while wiring up a
+// command (dialing L1/L2, looking up the rollup ID, starting a reorg
+// detector or syncer, initializing a signer) a stable identity, instead of
+// a bare wrapped error whose category can only be guessed from its message.
+// A supervisor or test harness can then errors.Is/errors.As against one of
+// the sentinels below to decide whether a failure is worth retrying.
+package spammererr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors identifying which startup stage failed. Wrap joins one of
+// these with the underlying error so both remain visible to errors.Is.
+var (
+	ErrL1Dial        = errors.New("failed to dial L1 client")
+	ErrL2Dial        = errors.New("failed to dial L2 client")
+	ErrRollupLookup  = errors.New("failed to look up rollup ID")
+	ErrReorgDetector = errors.New("failed to start reorg detector")
+	ErrSyncerStart   = errors.New("failed to start syncer")
+	ErrSignerInit    = errors.New("failed to initialize signer")
+)
+
+// Wrap joins sentinel and err so that errors.Is(result, sentinel) is true
+// and err's own detail (the dial address, the underlying network error...)
+// is still readable in the returned error's message. It returns nil if err
+// is nil.
+func Wrap(sentinel, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", sentinel, err)
+}
+
+// ExitCode maps err to a distinct process exit code by the sentinel it was
+// Wrap'd with, so a calling script can tell "L1 unreachable" apart from
+// "rollup ID lookup failed" without parsing stderr. Errors not wrapped with
+// one of this package's sentinels get the default exit code 1.
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrL1Dial):
+		return 10
+	case errors.Is(err, ErrL2Dial):
+		return 11
+	case errors.Is(err, ErrRollupLookup):
+		return 12
+	case errors.Is(err, ErrReorgDetector):
+		return 13
+	case errors.Is(err, ErrSyncerStart):
+		return 14
+	case errors.Is(err, ErrSignerInit):
+		return 15
+	default:
+		return 1
+	}
+}
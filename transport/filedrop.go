@@ -0,0 +1,71 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ARR552/agglayer_certificate_spammer/corpus"
+)
+
+const fileDropDirPerm = 0o755
+
+// FileDropTransport "sends" a certificate by atomically writing it to a
+// watched directory instead of talking to a real agglayer, so a run can be
+// fed to another process or inspected offline. The hash it reports is the
+// same content-address corpus.Hash computes, so a file-drop capture can be
+// loaded straight into a corpus.Store.
+type FileDropTransport struct {
+	dir string
+}
+
+// NewFileDropTransport returns a FileDropTransport rooted at dir, creating
+// it if needed.
+func NewFileDropTransport(dir string) (*FileDropTransport, error) {
+	if err := os.MkdirAll(dir, fileDropDirPerm); err != nil {
+		return nil, fmt.Errorf("error creating file-drop transport dir %s: %w", dir, err)
+	}
+	return &FileDropTransport{dir: dir}, nil
+}
+
+// SendCertificate atomically writes signedCertificate to the watched
+// directory and returns its content-address.
+func (t *FileDropTransport) SendCertificate(signedCertificate *agglayer.SignedCertificate) (common.Hash, error) {
+	hash, err := corpus.Hash(signedCertificate)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	raw, err := json.MarshalIndent(signedCertificate, "", "  ")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error marshalling certificate %s: %w", hash, err)
+	}
+
+	tmpFile, err := os.CreateTemp(t.dir, "cert-*.json.tmp")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error creating temp file for certificate %s: %w", hash, err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(raw); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return common.Hash{}, fmt.Errorf("error writing certificate %s: %w", hash, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return common.Hash{}, fmt.Errorf("error closing certificate %s: %w", hash, err)
+	}
+
+	finalPath := filepath.Join(t.dir, hash+".json")
+	if err := os.Rename(tmpPath, finalPath); err != nil {
+		os.Remove(tmpPath)
+		return common.Hash{}, fmt.Errorf("error dropping certificate %s into %s: %w", hash, t.dir, err)
+	}
+
+	return common.HexToHash(hash), nil
+}
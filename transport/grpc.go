@@ -0,0 +1,93 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	submitCertificateMethod    = "/agglayer.v1.CertificateSubmissionService/SubmitCertificate"
+	getCertificateStatusMethod = "/agglayer.v1.CertificateSubmissionService/GetCertificateStatus"
+	jsonCodecName              = "json"
+)
+
+// jsonCodec lets GRPCTransport call an agglayer gRPC service without
+// depending on its generated protobuf stubs: requests/responses are plain
+// Go structs with json tags, marshalled the same way the HTTP client would.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return jsonCodecName }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// GRPCTransport sends certificates to a gRPC agglayer instead of the default
+// HTTP/JSON one, similar to the CA service pattern used elsewhere for
+// submit/status RPCs over a single connection.
+type GRPCTransport struct {
+	conn *grpc.ClientConn
+}
+
+// NewGRPCTransport dials addr and returns a GRPCTransport backed by it.
+func NewGRPCTransport(addr string) (*GRPCTransport, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("error dialing agglayer grpc transport at %s: %w", addr, err)
+	}
+	return &GRPCTransport{conn: conn}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (t *GRPCTransport) Close() error {
+	return t.conn.Close()
+}
+
+type submitCertificateRequest struct {
+	Certificate *agglayer.SignedCertificate `json:"certificate"`
+}
+
+type submitCertificateResponse struct {
+	CertificateHash common.Hash `json:"certificateHash"`
+}
+
+// SendCertificate submits signedCertificate to the gRPC agglayer and returns
+// the hash it assigned it.
+func (t *GRPCTransport) SendCertificate(signedCertificate *agglayer.SignedCertificate) (common.Hash, error) {
+	req := &submitCertificateRequest{Certificate: signedCertificate}
+	var resp submitCertificateResponse
+	if err := t.conn.Invoke(context.Background(), submitCertificateMethod, req, &resp,
+		grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return common.Hash{}, fmt.Errorf("error submitting certificate over grpc: %w", err)
+	}
+	return resp.CertificateHash, nil
+}
+
+type getCertificateStatusRequest struct {
+	CertificateHash common.Hash `json:"certificateHash"`
+}
+
+type getCertificateStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// GetCertificateStatus fetches the status the gRPC agglayer has recorded for
+// certificateHash.
+func (t *GRPCTransport) GetCertificateStatus(certificateHash common.Hash) (string, error) {
+	req := &getCertificateStatusRequest{CertificateHash: certificateHash}
+	var resp getCertificateStatusResponse
+	if err := t.conn.Invoke(context.Background(), getCertificateStatusMethod, req, &resp,
+		grpc.CallContentSubtype(jsonCodecName)); err != nil {
+		return "", fmt.Errorf("error getting certificate status over grpc: %w", err)
+	}
+	return resp.Status, nil
+}
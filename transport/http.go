@@ -0,0 +1,23 @@
+package transport
+
+import (
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HTTPTransport sends certificates through the existing agglayer HTTP/JSON
+// client. It is the default transport.
+type HTTPTransport struct {
+	client agglayer.AgglayerClientInterface
+}
+
+// NewHTTPTransport wraps an already-constructed agglayer client as a
+// CertificateTransport.
+func NewHTTPTransport(client agglayer.AgglayerClientInterface) *HTTPTransport {
+	return &HTTPTransport{client: client}
+}
+
+// SendCertificate delegates to the wrapped agglayer client.
+func (t *HTTPTransport) SendCertificate(signedCertificate *agglayer.SignedCertificate) (common.Hash, error) {
+	return t.client.SendCertificate(signedCertificate)
+}
@@ -0,0 +1,18 @@
+// Package transport abstracts how a signed certificate actually reaches an
+// agglayer, so AggSender's retry/store logic stays the same whether it is
+// talking to a real HTTP agglayer, a gRPC one, or just writing certificates
+// to a watched directory for offline inspection. This lets the spammer
+// target mocked agglayers, offline captures, or alternative aggregation
+// services without recompiling.
+package transport
+
+import (
+	"github.com/0xPolygon/cdk/agglayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CertificateTransport sends a signed certificate somewhere and reports the
+// hash the destination assigned it.
+type CertificateTransport interface {
+	SendCertificate(signedCertificate *agglayer.SignedCertificate) (common.Hash, error)
+}